@@ -0,0 +1,183 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+)
+
+// resourceClient gets the Certificates, Issuers, ClusterIssuers and CertificateRequests
+// the status is built from, so that they can also be read from a fork serving them
+// under a different API group than cert-manager.io.
+type resourceClient interface {
+	getCertificate(ctx context.Context, namespace, name string) (*cmapi.Certificate, error)
+	listCertificates(ctx context.Context, namespace string, opts metav1.ListOptions) (*cmapi.CertificateList, error)
+	getIssuer(ctx context.Context, namespace, name string) (*cmapi.Issuer, error)
+	getClusterIssuer(ctx context.Context, name string) (*cmapi.ClusterIssuer, error)
+	listIssuers(ctx context.Context, namespace string, opts metav1.ListOptions) (*cmapi.IssuerList, error)
+	listCertificateRequests(ctx context.Context, namespace string) (*cmapi.CertificateRequestList, error)
+}
+
+// typedResourceClient reads the resources from cert-manager.io using the generated clientset
+type typedResourceClient struct {
+	cmClient cmclient.Interface
+}
+
+func (c typedResourceClient) getCertificate(ctx context.Context, namespace, name string) (*cmapi.Certificate, error) {
+	return c.cmClient.CertmanagerV1().Certificates(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (c typedResourceClient) listCertificates(ctx context.Context, namespace string, opts metav1.ListOptions) (*cmapi.CertificateList, error) {
+	return c.cmClient.CertmanagerV1().Certificates(namespace).List(ctx, opts)
+}
+
+func (c typedResourceClient) getIssuer(ctx context.Context, namespace, name string) (*cmapi.Issuer, error) {
+	return c.cmClient.CertmanagerV1().Issuers(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (c typedResourceClient) getClusterIssuer(ctx context.Context, name string) (*cmapi.ClusterIssuer, error) {
+	return c.cmClient.CertmanagerV1().ClusterIssuers().Get(ctx, name, metav1.GetOptions{})
+}
+
+func (c typedResourceClient) listIssuers(ctx context.Context, namespace string, opts metav1.ListOptions) (*cmapi.IssuerList, error) {
+	return c.cmClient.CertmanagerV1().Issuers(namespace).List(ctx, opts)
+}
+
+func (c typedResourceClient) listCertificateRequests(ctx context.Context, namespace string) (*cmapi.CertificateRequestList, error) {
+	return c.cmClient.CertmanagerV1().CertificateRequests(namespace).List(ctx, metav1.ListOptions{})
+}
+
+// dynamicResourceClient reads the resources from version of group using the dynamic client,
+// converting them into the cert-manager.io types
+type dynamicResourceClient struct {
+	client  dynamic.Interface
+	group   string
+	version string
+}
+
+func (c dynamicResourceClient) resource(resource string) schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: c.group, Version: c.version, Resource: resource}
+}
+
+func (c dynamicResourceClient) get(ctx context.Context, resource, namespace, name string, into runtime.Object) error {
+	obj, err := c.client.Resource(c.resource(resource)).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), into)
+}
+
+func (c dynamicResourceClient) list(ctx context.Context, resource, namespace string, opts metav1.ListOptions, into runtime.Object) error {
+	list, err := c.client.Resource(c.resource(resource)).Namespace(namespace).List(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(list.UnstructuredContent(), into)
+}
+
+func (c dynamicResourceClient) getCertificate(ctx context.Context, namespace, name string) (*cmapi.Certificate, error) {
+	crt := &cmapi.Certificate{}
+	if err := c.get(ctx, "certificates", namespace, name, crt); err != nil {
+		return nil, err
+	}
+	return crt, nil
+}
+
+func (c dynamicResourceClient) listCertificates(ctx context.Context, namespace string, opts metav1.ListOptions) (*cmapi.CertificateList, error) {
+	crts := &cmapi.CertificateList{}
+	if err := c.list(ctx, "certificates", namespace, opts, crts); err != nil {
+		return nil, err
+	}
+	return crts, nil
+}
+
+func (c dynamicResourceClient) getIssuer(ctx context.Context, namespace, name string) (*cmapi.Issuer, error) {
+	issuer := &cmapi.Issuer{}
+	if err := c.get(ctx, "issuers", namespace, name, issuer); err != nil {
+		return nil, err
+	}
+	return issuer, nil
+}
+
+func (c dynamicResourceClient) getClusterIssuer(ctx context.Context, name string) (*cmapi.ClusterIssuer, error) {
+	clusterIssuer := &cmapi.ClusterIssuer{}
+	if err := c.get(ctx, "clusterissuers", "", name, clusterIssuer); err != nil {
+		return nil, err
+	}
+	return clusterIssuer, nil
+}
+
+func (c dynamicResourceClient) listIssuers(ctx context.Context, namespace string, opts metav1.ListOptions) (*cmapi.IssuerList, error) {
+	issuers := &cmapi.IssuerList{}
+	if err := c.list(ctx, "issuers", namespace, opts, issuers); err != nil {
+		return nil, err
+	}
+	return issuers, nil
+}
+
+func (c dynamicResourceClient) listCertificateRequests(ctx context.Context, namespace string) (*cmapi.CertificateRequestList, error) {
+	reqs := &cmapi.CertificateRequestList{}
+	if err := c.list(ctx, "certificaterequests", namespace, metav1.ListOptions{}, reqs); err != nil {
+		return nil, err
+	}
+	return reqs, nil
+}
+
+// preferredAPIGroupVersion returns the version of group preferred by the API server, e.g. v1alpha1 for
+// certmanager.k8s.io. Returns an error if group is not served or its preferred version does not contain
+// the certificates resource.
+func preferredAPIGroupVersion(discoveryClient discovery.DiscoveryInterface, group string) (string, error) {
+	groups, err := discoveryClient.ServerGroups()
+	if err != nil {
+		return "", fmt.Errorf("error when checking that API group %q is served: %w", group, err)
+	}
+	var groupVersion string
+	for _, apiGroup := range groups.Groups {
+		if apiGroup.Name == group {
+			groupVersion = apiGroup.PreferredVersion.GroupVersion
+			break
+		}
+	}
+	if groupVersion == "" {
+		return "", fmt.Errorf("API group %q is not served", group)
+	}
+
+	resources, err := discoveryClient.ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		return "", fmt.Errorf("error when checking that API group %q is served: %w", group, err)
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == "certificates" {
+			gv, err := schema.ParseGroupVersion(groupVersion)
+			if err != nil {
+				return "", err
+			}
+			return gv.Version, nil
+		}
+	}
+	return "", fmt.Errorf("API group version %q is served but has no certificates resource", groupVersion)
+}
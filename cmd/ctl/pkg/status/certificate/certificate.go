@@ -17,26 +17,46 @@ limitations under the License.
 package certificate
 
 import (
+	"bytes"
 	"context"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/reference"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/utils/clock"
+	utilexec "k8s.io/utils/exec"
 
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1beta1"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	"github.com/jetstack/cert-manager/pkg/ctl"
+	dnsutil "github.com/jetstack/cert-manager/pkg/issuer/acme/dns/util"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
 	"github.com/jetstack/cert-manager/pkg/util/predicate"
 )
 
@@ -47,9 +67,84 @@ Get details about the current status of a cert-manager Certificate resource, inc
 	example = templates.Examples(i18n.T(`
 # Query status of Certificate with name 'my-crt' in namespace 'my-namespace'
 kubectl cert-manager status certificate my-crt --namespace my-namespace
+
+# Quickly check Certificate with name 'my-crt', printing only a compact summary of its status
+kubectl cert-manager status certificate my-crt --format summary
+
+# Query status of Certificate with name 'my-crt' as JSON or YAML, for consumption by scripts
+kubectl cert-manager status certificate my-crt -o json
+kubectl cert-manager status certificate my-crt -o yaml
+
+# Print only the serial number and the renewal time of Certificate with name 'my-crt'
+kubectl cert-manager status certificate my-crt -o go-template='{{.SecretStatus.SerialNumber}} {{.RenewalTime}}'
+
+# Follow the issuance of Certificate with name 'my-crt', printing a summary whenever it or its related resources change until it is Ready
+kubectl cert-manager status certificate my-crt --watch --format summary
+
+# Stream the status of Certificate with name 'my-crt' as one line of JSON per change, e.g. to feed a dashboard
+kubectl cert-manager status certificate my-crt --watch -o jsonl
+
+# Quickly check all Certificates with the label 'app=my-app' in all namespaces
+kubectl cert-manager status certificate -l app=my-app --all-namespaces --format summary
+
+# Print a table with a row per Certificate in all namespaces, like 'kubectl get'
+kubectl cert-manager status certificate --all-namespaces -o wide
+
+# Query status of Certificate with name 'my-crt' and also write the output to the file 'my-crt-status.log'
+kubectl cert-manager status certificate my-crt --tee my-crt-status.log
+
+# Print the certificate chain stored in the Secret of Certificate 'my-crt', each certificate preceded by its subject and issuer
+kubectl cert-manager status certificate my-crt --dump-chain-annotated
+
+# Export the certificate of Certificate 'my-crt' followed by its CA certificate to the file 'my-crt.pem' to inspect it elsewhere
+kubectl cert-manager status certificate my-crt --export-cert my-crt.pem --export-chain
+
+# Query status of Certificate with name 'my-crt' and warn if its signature algorithm is weaker than SHA-384
+kubectl cert-manager status certificate my-crt --min-signature-strength sha384
+
+# Query status of Certificate with name 'my-crt' and warn if it lacks the CA/Browser Forum domain validated policy
+kubectl cert-manager status certificate my-crt --require-policy 2.23.140.1.2.1
+
+# Fail a CI job if Certificate with name 'my-crt' is not Ready or is signed with an algorithm weaker than SHA-256
+kubectl cert-manager status certificate my-crt --format summary --exit-code --min-signature-strength sha256
+
+# Query status of Certificate with name 'my-crt' without looking up and printing events, e.g. for quieter CI logs
+kubectl cert-manager status certificate my-crt --show-events=false
+
+# Query status of Certificate with name 'my-crt' of a fork of cert-manager serving its resources under the group 'certmanager.example.com'
+kubectl cert-manager status certificate my-crt --api-group certmanager.example.com
+
+# Inspect the certificate in a Secret dumped with 'kubectl get secret -o yaml', or in a PEM file, without access to the cluster
+kubectl cert-manager status certificate --from-file my-tls-secret.yaml
+kubectl cert-manager status certificate --from-file tls.crt
+
+# Query status of Certificate with name 'my-crt' and check whether the TXT records of its DNS01 challenges have propagated to 8.8.8.8 and 1.1.1.1
+kubectl cert-manager status certificate my-crt --check-dns --dns-nameservers 8.8.8.8,1.1.1.1 --timeout 5s
+
+# Query status of Certificate with name 'my-crt', giving up on the API server after 30 seconds
+kubectl cert-manager status certificate my-crt --lookup-timeout 30s
 `))
 )
 
+// Exit codes of the command when --exit-code is set and the Certificate is unhealthy.
+// If more than one applies, the first one listed here is used.
+const (
+	// exitCodeLookupError is used if getting the Issuer, Secret or CertificateRequest of the Certificate failed,
+	// other than the Secret or the next CertificateRequest not existing
+	exitCodeLookupError = 2
+	// exitCodeNotReady is used if the Ready condition of the Certificate is missing or not True
+	exitCodeNotReady = 1
+	// exitCodeSignaturePolicy is used if the signature algorithm of the certificate is below --min-signature-strength
+	exitCodeSignaturePolicy = 3
+	// exitCodeWarningError is used if any of the Warnings of the Certificate has the error severity,
+	// e.g. the certificate in the Secret has expired or does not match its private key
+	exitCodeWarningError = 4
+)
+
+// errNoCertificateRequest is the error of the CertificateRequest status when no CertificateRequest exists
+// for the next revision of the Certificate, which is expected once the Certificate has been issued
+var errNoCertificateRequest = errors.New("No CertificateRequest found for this Certificate\n")
+
 // Options is a struct to support status certificate command
 type Options struct {
 	CMClient   cmclient.Interface
@@ -57,34 +152,145 @@ type Options struct {
 	// The Namespace that the Certificate to be queried about resides in.
 	// This flag registration is handled by cmdutil.Factory
 	Namespace string
+	// Name of a file that the output is written to in addition to stdout.
+	// If not specified, the output is only written to stdout
+	TeeFilename string
+	// Label selector of the Certificates to print the status of, instead of a single Certificate given by name
+	LabelSelector string
+	// If true, print the status of the Certificates in all namespaces, instead of in Namespace
+	AllNamespaces bool
+	// If true, look for namespaced Issuers that have the same name as the ClusterIssuer of the Certificate
+	CheckSameNameIssuers bool
+	// If true, look for other Certificates in the namespace of the Certificate that have the same Secret Name
+	CheckSecretContention bool
+	// If true, print the details and events of every CertificateRequest of the Certificate,
+	// not only of the one for its next revision
+	History bool
+	// If true, look up and print the events of the Certificate and its related resources
+	ShowEvents bool
+	// Type of the events to print, one of eventTypes. If empty, events of all types are printed
+	EventType string
+	// If true, print the certificate chain in 'tls.crt' of the Secret as stored instead of the status
+	DumpChain bool
+	// If true, print the certificate chain in 'tls.crt' of the Secret instead of the status,
+	// with a comment header before each certificate stating its index, subject and issuer
+	DumpChainAnnotated bool
+	// Name of a file to write the PEM encoded certificates in 'tls.crt' of the Secret to exactly as stored,
+	// instead of printing the status. If "-", they are written to stdout
+	ExportCert string
+	// If true, --export-cert also writes the PEM encoded CA certificate in 'ca.crt' of the Secret after them
+	ExportChain bool
+	// Format of the status output, either "full" or "summary"
+	Format string
+	// Output format of the status for machine consumption, "json", "yaml", "go-template=TEMPLATE",
+	// "go-template-file=FILENAME" or empty for the human readable text. "wide" prints a table with a row per Certificate,
+	// "metrics" prints the expiry, renewal and readiness of each Certificate in the Prometheus text format.
+	// In Watch mode, "jsonl" prints each refresh as a single line of JSON
+	Output string
+	// Template parsed from Output if it is one of the go-template formats
+	outputTemplate *template.Template
+	// Minimum strength tier of the signature algorithm of the certificate, one of signatureStrengthTiers.
+	// If empty, the signature algorithm is not assessed
+	MinSignatureStrength string
+	// OIDs in dotted notation that the Certificate Policies of the certificate must include.
+	// A warning is printed for each that is missing
+	RequirePolicies []string
+	// If true, keep polling the Certificate and its related resources every Interval,
+	// printing the status again whenever any of them changes, until the Certificate is Ready
+	Watch bool
+	// Interval between polls in Watch mode
+	Interval time.Duration
+	// If true, exit with a non-zero code after printing the status if the Certificate is unhealthy,
+	// see exitCodeNotReady, exitCodeLookupError, exitCodeSignaturePolicy and exitCodeWarningError
+	ExitCode bool
+	// If true, print the serial number of the certificate as the content of its DER INTEGER encoding
+	SerialDER bool
+	// If true, print every field and extension of the certificate, as with 'openssl x509 -text'
+	RawX509 bool
+	// If true, print a checklist of the steps of the issuance of the Certificate instead of its status,
+	// each as passed, failed or skipped
+	Diagnose bool
+	// Hash algorithm of the fingerprint of the certificate to print, one of fingerprintAlgorithms
+	FingerprintAlgorithm string
+	// Whether to color the human readable status, one of colorModes
+	Color string
+	// If true, also verify the certificate chain against the trust store of the host, not only against 'ca.crt' of the Secret
+	VerifyAgainstSystemRoots bool
+	// Password of 'keystore.p12' of the Secret. If set, the keystore is decoded to check that it holds the certificate
+	PKCS12Password string
+	// If true, look up the TXT record of each DNS01 challenge in progress to check if it has propagated
+	CheckDNS bool
+	// Nameservers queried by CheckDNS, as host or host:port.
+	// If empty, the nameservers of the local resolver configuration are used
+	DNSNameservers []string
+	// Endpoint, as host:port, to make a TLS handshake with to check that it serves the certificate in the Secret.
+	// If empty, no endpoint is probed
+	Probe string
+	// Timeout of each DNS query made by CheckDNS and of the handshake made by Probe.
+	// If zero, the default DNS timeout is used and the handshake does not time out
+	Timeout time.Duration
+	// Timeout of the API lookups of the resources of each Certificate, including their retries.
+	// If zero, the lookups do not time out
+	LookupTimeout time.Duration
+	// API group that Certificates, Issuers, ClusterIssuers and CertificateRequests are read from,
+	// for forks of cert-manager serving them under a different group. If empty, cert-manager.io is used.
+	// ACME Orders and Challenges are always read from acme.cert-manager.io
+	APIGroup string
+	// Namespace that ClusterIssuers read their Secrets from,
+	// matching the --cluster-resource-namespace flag of the cert-manager controller
+	ClusterResourceNamespace string
+	// Name of a file holding a Secret manifest or PEM encoded certificates to print the status of,
+	// instead of querying the cluster for a Certificate
+	FromFile string
+	// RFC3339 time that relative times such as expiry and ages are computed from instead of the current time,
+	// so that the output is reproducible. If empty, the current time is used
+	Now string
+	// Time parsed from Now
+	now time.Time
+	// If true, print to ErrOut how many attempts the lookups of the resources of each Certificate took,
+	// as lookups that fail with a transient error are retried
+	Verbose bool
 
 	genericclioptions.IOStreams
 }
 
 // Data is a struct containing the information to build a CertificateStatus
 type Data struct {
-	Certificate  *cmapi.Certificate
-	CrtEvents    *corev1.EventList
-	Issuer       cmapi.GenericIssuer
-	IssuerKind   string
-	IssuerError  error
-	IssuerEvents *corev1.EventList
-	Secret       *corev1.Secret
-	SecretError  error
-	SecretEvents *corev1.EventList
-	Req          *cmapi.CertificateRequest
-	ReqError     error
-	ReqEvents    *corev1.EventList
-	Order        *cmacme.Order
-	OrderError   error
-	Challenges   []*cmacme.Challenge
-	ChallengeErr error
+	Certificate               *cmapi.Certificate
+	CrtEvents                 *corev1.EventList
+	Issuer                    cmapi.GenericIssuer
+	IssuerKind                string
+	IssuerGroup               string
+	ExternalIssuer            bool
+	IssuerError               error
+	IssuerEvents              *corev1.EventList
+	SameNameIssuerNamespaces  []string
+	IssuerCASecret            *corev1.Secret
+	IssuerCASecretError       error
+	SecretContention          []string
+	Secret                    *corev1.Secret
+	SecretError               error
+	NextPrivateKeySecret      *corev1.Secret
+	NextPrivateKeySecretError error
+	SecretEvents              *corev1.EventList
+	Req                       *cmapi.CertificateRequest
+	ReqError                  error
+	ReqEvents                 *corev1.EventList
+	ReqHistory                []*cmapi.CertificateRequest
+	ReqHistoryEvents          map[string]*corev1.EventList
+	Order                     *cmacme.Order
+	OrderError                error
+	Challenges                []*cmacme.Challenge
+	ChallengeErr              error
+	ChallengeDNSLookups       map[string][]DNSLookup
+	Probe                     *ProbeResult
 }
 
 // NewOptions returns initialized Options
 func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
 	return &Options{
-		IOStreams: ioStreams,
+		IOStreams:  ioStreams,
+		ShowEvents: true,
 	}
 }
 
@@ -102,22 +308,197 @@ func NewCmdStatusCert(ioStreams genericclioptions.IOStreams, factory cmdutil.Fac
 			cmdutil.CheckErr(o.Run(args))
 		},
 	}
+	cmd.Flags().StringVarP(&o.LabelSelector, "selector", "l", o.LabelSelector,
+		"Label selector of the Certificates to print the status of, instead of a single Certificate given by name (e.g. -l key1=value1,key2=value2)")
+	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces,
+		"If true, print the status of the Certificates in all namespaces, optionally matching --selector")
+	cmd.Flags().StringVar(&o.FromFile, "from-file", o.FromFile,
+		"Name of a file holding a Secret manifest or PEM encoded certificates to print the status of without querying the cluster. go-template output is executed on the status of the Secret")
+	cmd.Flags().StringVar(&o.TeeFilename, "tee", o.TeeFilename,
+		"Name of a file that the output is also written to, in addition to stdout")
+	cmd.Flags().BoolVar(&o.CheckSameNameIssuers, "check-same-name-issuers", o.CheckSameNameIssuers,
+		"If true and the Certificate references a ClusterIssuer, look for namespaced Issuers with the same name across all namespaces")
+	cmd.Flags().BoolVar(&o.CheckSecretContention, "check-secret-contention", o.CheckSecretContention,
+		"If true, look for other Certificates in the namespace of the Certificate that have the same Secret Name and would fight over the Secret")
+	cmd.Flags().BoolVar(&o.History, "history", o.History,
+		"If true, print the details and events of every CertificateRequest of the Certificate, not only a line per CertificateRequest")
+	cmd.Flags().BoolVar(&o.ShowEvents, "show-events", o.ShowEvents,
+		"If true, look up and print the events of the Certificate and its related resources. Set to false for quieter output in CI logs and scripts")
+	cmd.Flags().StringVar(&o.EventType, "event-type", eventTypeAll,
+		fmt.Sprintf("Type of the events to print, one of: %s. The number of events left out is printed with each table of events", strings.Join(eventTypes, ", ")))
+	cmd.Flags().BoolVar(&o.DumpChain, "dump-chain", o.DumpChain,
+		"If true, print the PEM encoded certificate chain of the Secret exactly as stored instead of the status")
+	cmd.Flags().BoolVar(&o.DumpChainAnnotated, "dump-chain-annotated", o.DumpChainAnnotated,
+		"If true, print the PEM encoded certificate chain of the Secret instead of the status, with a comment header before each certificate stating its index, subject and issuer")
+	cmd.Flags().StringVar(&o.ExportCert, "export-cert", o.ExportCert,
+		"Name of a file to write the PEM encoded certificate in 'tls.crt' of the Secret to exactly as stored, instead of printing the status. Use - for stdout")
+	cmd.Flags().BoolVar(&o.ExportChain, "export-chain", o.ExportChain,
+		"If true, --export-cert also writes the CA certificate in 'ca.crt' of the Secret after the certificate")
+	cmd.Flags().StringVar(&o.Format, "format", "full",
+		"Format of the status output, one of: full, summary. summary prints the most important facts in a single compact block")
+	cmd.Flags().StringVarP(&o.Output, "output", "o", o.Output,
+		"Output format of the status for scripts, one of: json, yaml, jsonl, wide, metrics, go-template=TEMPLATE, go-template-file=FILENAME. wide prints a table with a row per Certificate, metrics prints the expiry, renewal and readiness of each Certificate in the Prometheus text format, jsonl prints each refresh of --watch as a single line of JSON with a timestamp. If not specified, the status is printed as human readable text")
+	cmd.Flags().StringVar(&o.MinSignatureStrength, "min-signature-strength", o.MinSignatureStrength,
+		fmt.Sprintf("Minimum strength of the signature algorithm of the certificate, one of: %s. A warning is printed if the certificate is below it", strings.Join(signatureStrengthTiers, ", ")))
+	cmd.Flags().StringSliceVar(&o.RequirePolicies, "require-policy", o.RequirePolicies,
+		"OID in dotted notation of a Certificate Policy that the certificate must have, e.g. 2.23.140.1.2.1. A warning is printed if it is missing. Can be repeated")
+	cmd.Flags().BoolVarP(&o.Watch, "watch", "w", o.Watch,
+		"If true, keep printing the status whenever the Certificate or its related resources change, until the Certificate is Ready or interrupted")
+	cmd.Flags().DurationVar(&o.Interval, "interval", 2*time.Second,
+		"Interval between polls of the Certificate and its related resources in --watch mode")
+	cmd.Flags().BoolVar(&o.ExitCode, "exit-code", o.ExitCode,
+		fmt.Sprintf("If true, exit after printing the status with code %d if the Certificate is not Ready, %d if getting its Issuer, Secret or CertificateRequest failed, %d if its signature algorithm is below --min-signature-strength, or %d if any warning has the error severity",
+			exitCodeNotReady, exitCodeLookupError, exitCodeSignaturePolicy, exitCodeWarningError))
+	cmd.Flags().BoolVar(&o.SerialDER, "serial-der", o.SerialDER,
+		"If true, print the serial number of the certificate as encoded in DER, keeping the leading 00 byte that CAs show for serials with the high bit set")
+	cmd.Flags().BoolVar(&o.RawX509, "raw-x509", o.RawX509,
+		"If true, also print every field and extension of the certificate in the Secret in a layout like 'openssl x509 -text', including extensions that are only shown as hex. Only applies to the human readable status")
+	cmd.Flags().BoolVar(&o.Diagnose, "diagnose", o.Diagnose,
+		"If true, print a checklist of the steps of the issuance instead of the status: the Issuer is Ready, the CertificateRequest is Ready, the Secret parses, its key matches the certificate, the certificate is within its validity and its chain verifies. Steps after the first failing one are skipped")
+	cmd.Flags().StringVar(&o.FingerprintAlgorithm, "fingerprint-algorithm", fingerprintAlgorithmSHA256,
+		fmt.Sprintf("Hash algorithm of the fingerprint of the certificate to print, one of: %s", strings.Join(fingerprintAlgorithms, ", ")))
+	cmd.Flags().StringVar(&o.Color, "color", colorModeAuto,
+		fmt.Sprintf("Whether to color conditions and warnings in the human readable status, one of: %s. In auto mode, colors are used if the output is a terminal", strings.Join(colorModes, ", ")))
+	cmd.Flags().BoolVar(&o.VerifyAgainstSystemRoots, "verify-against-system-roots", o.VerifyAgainstSystemRoots,
+		"If true, also verify that the certificate chains to a root trusted by this host, not only to 'ca.crt' of the Secret")
+	cmd.Flags().StringVar(&o.PKCS12Password, "pkcs12-password", o.PKCS12Password,
+		"Password of the PKCS#12 keystore in the Secret. If set, the keystore is decoded to check that it holds the same certificate as 'tls.crt'")
+	cmd.Flags().BoolVar(&o.CheckDNS, "check-dns", o.CheckDNS,
+		"If true, look up the _acme-challenge TXT record of each DNS01 challenge in progress and report whether the expected value is visible")
+	cmd.Flags().StringSliceVar(&o.DNSNameservers, "dns-nameservers", o.DNSNameservers,
+		"Nameservers queried by --check-dns, as host or host:port. Defaults to the nameservers of the local resolver configuration")
+	cmd.Flags().StringVar(&o.Probe, "probe", o.Probe,
+		"Endpoint, as host:port, to make a TLS handshake with to check that it serves the certificate in the Secret and that the certificate is valid for the host")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", dnsutil.DNSTimeout,
+		"Timeout of each DNS query made by --check-dns and of the TLS handshake made by --probe")
+	cmd.Flags().DurationVar(&o.LookupTimeout, "lookup-timeout", o.LookupTimeout,
+		"Timeout of the lookups of the resources related to each Certificate, including their retries. Zero means no timeout")
+	cmd.Flags().StringVar(&o.APIGroup, "api-group", cmapi.SchemeGroupVersion.Group,
+		"API group that Certificates, Issuers, ClusterIssuers and CertificateRequests are read from, in the version preferred by the API server, for forks of cert-manager serving them under a different group. ACME Orders and Challenges are always read from acme.cert-manager.io")
+	cmd.Flags().StringVar(&o.ClusterResourceNamespace, "cluster-resource-namespace", "cert-manager",
+		"Namespace that ClusterIssuers read their Secrets from, as configured on the cert-manager controller")
+	cmd.Flags().BoolVar(&o.Verbose, "verbose", o.Verbose,
+		"If true, print to stderr how many attempts the lookups of the resources of each Certificate took, as lookups that fail with a transient API error are retried")
+	cmd.Flags().StringVar(&o.Now, "now", o.Now,
+		"RFC3339 time to compute relative times from instead of the current time, for reproducible output")
+	// Only meant for tests and reports that need the same output on every run
+	cmd.Flags().MarkHidden("now")
 	return cmd
 }
 
 // Validate validates the provided options
 func (o *Options) Validate(args []string) error {
-	if len(args) < 1 {
+	if o.FromFile != "" {
+		if len(args) > 0 || o.listsCertificates() {
+			return errors.New("cannot pass the name of a Certificate, --selector or --all-namespaces in conjunction with --from-file")
+		}
+		if o.Watch || o.ExitCode || o.Format == "summary" {
+			return errors.New("cannot specify --watch, --exit-code or --format summary in conjunction with --from-file")
+		}
+	} else if o.listsCertificates() {
+		if len(args) > 0 {
+			return errors.New("cannot pass the name of a Certificate in conjunction with --selector or --all-namespaces")
+		}
+		if o.Watch || o.DumpChain || o.DumpChainAnnotated || o.ExportCert != "" {
+			return errors.New("cannot specify --watch, --dump-chain, --dump-chain-annotated or --export-cert in conjunction with --selector or --all-namespaces")
+		}
+	} else if len(args) < 1 {
 		return errors.New("the name of the Certificate has to be provided as argument")
-	}
-	if len(args) > 1 {
+	} else if len(args) > 1 {
 		return errors.New("only one argument can be passed in: the name of the Certificate")
 	}
+	if o.Diagnose && (o.FromFile != "" || o.Output != "" || o.Format == "summary") {
+		return errors.New("cannot specify --diagnose in conjunction with --from-file, --output or --format summary")
+	}
+	if o.Probe != "" {
+		if o.FromFile != "" || o.listsCertificates() {
+			return errors.New("cannot specify --probe in conjunction with --from-file, --selector or --all-namespaces")
+		}
+		if err := validateProbeAddress(o.Probe); err != nil {
+			return fmt.Errorf("invalid --probe %q, must be host:port: %w", o.Probe, err)
+		}
+	}
+	if o.DumpChain && o.DumpChainAnnotated {
+		return errors.New("cannot specify --dump-chain in conjunction with --dump-chain-annotated")
+	}
+	if o.ExportCert != "" && (o.Watch || o.DumpChain || o.DumpChainAnnotated) {
+		return errors.New("cannot specify --export-cert in conjunction with --watch, --dump-chain or --dump-chain-annotated")
+	}
+	if o.ExportChain && o.ExportCert == "" {
+		return errors.New("cannot specify --export-chain without --export-cert")
+	}
+	if o.Format != "" && o.Format != "full" && o.Format != "summary" {
+		return fmt.Errorf("invalid --format %q, must be one of: full, summary", o.Format)
+	}
+	if isTemplateOutput(o.Output) {
+		tmpl, err := parseOutputTemplate(o.Output)
+		if err != nil {
+			return err
+		}
+		o.outputTemplate = tmpl
+	} else if o.Output == "jsonl" {
+		if !o.Watch {
+			return errors.New("--output jsonl can only be specified in conjunction with --watch")
+		}
+	} else if o.Output == "wide" || o.Output == "metrics" {
+		if o.FromFile != "" {
+			return fmt.Errorf("cannot specify --output %s in conjunction with --from-file", o.Output)
+		}
+		if o.Output == "metrics" && o.Watch {
+			return errors.New("cannot specify --output metrics in conjunction with --watch")
+		}
+	} else if o.Output != "" && o.Output != "json" && o.Output != "yaml" {
+		return fmt.Errorf("invalid --output %q, must be one of: json, yaml, jsonl, wide, metrics, go-template=TEMPLATE, go-template-file=FILENAME", o.Output)
+	}
+	if o.Watch && (o.DumpChain || o.DumpChainAnnotated) {
+		return errors.New("cannot specify --watch in conjunction with --dump-chain or --dump-chain-annotated")
+	}
+	if o.Watch && o.Interval <= 0 {
+		return fmt.Errorf("invalid --interval %s, must be positive", o.Interval)
+	}
+	if o.Output != "" && o.Format == "summary" {
+		return errors.New("cannot specify --format summary in conjunction with --output")
+	}
+	if !isColorMode(o.Color) {
+		return fmt.Errorf("invalid --color %q, must be one of: %s", o.Color, strings.Join(colorModes, ", "))
+	}
+	if !isFingerprintAlgorithm(o.FingerprintAlgorithm) {
+		return fmt.Errorf("invalid --fingerprint-algorithm %q, must be one of: %s", o.FingerprintAlgorithm, strings.Join(fingerprintAlgorithms, ", "))
+	}
+	if !isEventType(o.EventType) {
+		return fmt.Errorf("invalid --event-type %q, must be one of: %s", o.EventType, strings.Join(eventTypes, ", "))
+	}
+	if o.MinSignatureStrength != "" && signatureStrengthRank(o.MinSignatureStrength) < 0 {
+		return fmt.Errorf("invalid --min-signature-strength %q, must be one of: %s", o.MinSignatureStrength, strings.Join(signatureStrengthTiers, ", "))
+	}
+	for i, policy := range o.RequirePolicies {
+		oid, err := parseOID(policy)
+		if err != nil {
+			return fmt.Errorf("invalid --require-policy: %w", err)
+		}
+		// Normalized, so that e.g. leading zeros do not make a present policy look missing
+		o.RequirePolicies[i] = oid.String()
+	}
+	if o.Now != "" {
+		if o.Watch {
+			return errors.New("cannot specify --now in conjunction with --watch")
+		}
+		now, err := time.Parse(time.RFC3339, o.Now)
+		if err != nil {
+			return fmt.Errorf("invalid --now %q, must be an RFC3339 time: %w", o.Now, err)
+		}
+		o.now = now
+	}
 	return nil
 }
 
 // Complete takes the factory and infers any remaining options.
 func (o *Options) Complete(f cmdutil.Factory) error {
+	// The cluster is not queried at all for a status read from a file
+	if o.FromFile != "" {
+		return nil
+	}
+
 	var err error
 
 	o.Namespace, _, err = f.ToRawKubeConfigLoader().Namespace()
@@ -140,19 +521,293 @@ func (o *Options) Complete(f cmdutil.Factory) error {
 
 // Run executes status certificate command
 func (o *Options) Run(args []string) error {
+	if o.FromFile != "" {
+		return o.runFromFile()
+	}
+	if o.listsCertificates() {
+		return o.runList()
+	}
+
 	data, err := o.GetResources(args[0])
 	if err != nil {
 		return err
 	}
 
-	// Build status of Certificate with data gathered
-	status := StatusFromResources(data)
+	if o.ExportCert != "" {
+		if data.SecretError != nil {
+			return data.SecretError
+		}
+		return o.exportCert(data.Secret)
+	}
 
-	fmt.Fprintf(o.Out, status.String())
+	out, closeOut, err := o.output()
+	if err != nil {
+		return err
+	}
+	defer closeOut()
+
+	if o.DumpChain || o.DumpChainAnnotated {
+		if data.SecretError != nil {
+			return data.SecretError
+		}
+		return writeChain(out, data.Secret, o.DumpChainAnnotated)
+	}
 
+	var status *CertificateStatus
+	if o.Watch {
+		stop := make(chan os.Signal, 1)
+		signal.Notify(stop, os.Interrupt)
+		defer signal.Stop(stop)
+		getData := func() (*Data, error) { return o.GetResources(args[0]) }
+		status, err = o.watch(out, data, getData, o.clock(), stop)
+	} else {
+		status = o.buildStatus(data, o.clock())
+		err = o.writeStatus(out, status)
+	}
+	if err != nil {
+		return err
+	}
+
+	if o.ExitCode {
+		if code, reason := status.exitCode(); code != 0 {
+			return utilexec.CodeExitError{Err: errors.New(reason), Code: code}
+		}
+	}
 	return nil
 }
 
+// runList prints the status of each Certificate matching o.LabelSelector, separated by "---" in the text output
+// or as a list in the JSON and YAML output
+func (o *Options) runList() error {
+	datas, err := o.GetResourcesForSelector()
+	if err != nil {
+		return err
+	}
+	if len(datas) == 0 {
+		if o.AllNamespaces {
+			fmt.Fprintln(o.ErrOut, "No Certificates found")
+		} else {
+			fmt.Fprintf(o.ErrOut, "No Certificates found in %s namespace\n", o.Namespace)
+		}
+		return nil
+	}
+
+	out, closeOut, err := o.output()
+	if err != nil {
+		return err
+	}
+	defer closeOut()
+
+	statuses := make([]*CertificateStatus, len(datas))
+	for i, data := range datas {
+		statuses[i] = o.buildStatus(data, o.clock())
+	}
+	switch {
+	case o.Output == "json":
+		err = writeJSON(out, statuses)
+	case o.Output == "yaml":
+		err = writeYAML(out, statuses)
+	case o.Output == "wide":
+		err = writeWide(out, statuses, o.AllNamespaces)
+	case o.Output == "metrics":
+		err = writeMetrics(out, statuses)
+	default:
+		for i, status := range statuses {
+			if i > 0 {
+				if _, err = fmt.Fprint(out, "---\n"); err != nil {
+					return err
+				}
+			}
+			if err = o.writeStatus(out, status); err != nil {
+				return err
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if o.ExitCode {
+		// Fail with the code of the first unhealthy Certificate
+		for _, status := range statuses {
+			if code, reason := status.exitCode(); code != 0 {
+				return utilexec.CodeExitError{Err: fmt.Errorf("Certificate %s/%s: %s", status.Namespace, status.Name, reason), Code: code}
+			}
+		}
+	}
+	return nil
+}
+
+// listsCertificates returns true if the status of the Certificates matching a selector is printed,
+// instead of the status of a single Certificate given by name
+func (o *Options) listsCertificates() bool {
+	return o.LabelSelector != "" || o.AllNamespaces
+}
+
+// output returns the writer the output is written to, which is also written to the --tee file if set,
+// and a function to close it
+func (o *Options) output() (io.Writer, func(), error) {
+	if o.TeeFilename == "" {
+		return o.Out, func() {}, nil
+	}
+	teeFile, err := os.Create(o.TeeFilename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error when creating file %q to write output to: %w", o.TeeFilename, err)
+	}
+	return io.MultiWriter(o.Out, teeFile), func() { teeFile.Close() }, nil
+}
+
+// clock returns the clock that the relative times in the status are computed from,
+// which is fixed at --now if it was specified
+func (o *Options) clock() clock.Clock {
+	if o.now.IsZero() {
+		return clock.RealClock{}
+	}
+	return fixedClock{now: o.now}
+}
+
+// fixedClock is a clock.Clock whose time does not advance. Timers still run on the real clock
+type fixedClock struct {
+	clock.RealClock
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+func (c fixedClock) Since(t time.Time) time.Duration {
+	return c.now.Sub(t)
+}
+
+// buildStatus builds the status of the Certificate from data, applying the options that affect the status
+func (o *Options) buildStatus(data *Data, clock clock.Clock) *CertificateStatus {
+	return o.withOptions(StatusFromResources(data, clock), data.Secret, clock)
+}
+
+// withOptions applies the options that affect the status of the certificate in secret to status
+func (o *Options) withOptions(status *CertificateStatus, secret *corev1.Secret, clock clock.Clock) *CertificateStatus {
+	return status.
+		withSignaturePolicy(o.MinSignatureStrength).
+		withRequiredPolicies(o.RequirePolicies).
+		withDERSerialNumber(o.SerialDER).
+		withFingerprintAlgorithm(o.FingerprintAlgorithm).
+		withRawX509(o.RawX509).
+		withChainVerification(secret, o.VerifyAgainstSystemRoots, clock).
+		withPKCS12Verification(secret, o.PKCS12Password).
+		withCRHistoryDetails(o.History).
+		withEventsShown(o.ShowEvents).
+		withEventTypeFilter(o.EventType)
+}
+
+// writeStatus writes status to out in the output format of o
+func (o *Options) writeStatus(out io.Writer, status *CertificateStatus) error {
+	var err error
+	switch {
+	case o.outputTemplate != nil:
+		err = o.outputTemplate.Execute(out, status)
+	case o.Output == "json":
+		err = status.ToJSON(out)
+	case o.Output == "yaml":
+		err = status.ToYAML(out)
+	case o.Output == "wide":
+		err = writeWide(out, []*CertificateStatus{status}, false)
+	case o.Output == "metrics":
+		err = writeMetrics(out, []*CertificateStatus{status})
+	case o.Format == "summary":
+		_, err = fmt.Fprint(out, status.CompactString())
+	case o.Diagnose && o.colorEnabled(out):
+		_, err = status.WriteDiagnosis(colorWriter{out})
+	case o.Diagnose:
+		_, err = status.WriteDiagnosis(out)
+	case o.colorEnabled(out):
+		_, err = status.WriteTo(colorWriter{out})
+	default:
+		_, err = status.WriteTo(out)
+	}
+	return err
+}
+
+// writeChain writes the PEM encoded certificate chain in 'tls.crt' of secret to out exactly as stored.
+// If annotated, each certificate is instead re-encoded and preceded by a comment header
+// stating its index in the chain, its subject and its issuer.
+func writeChain(out io.Writer, secret *corev1.Secret, annotated bool) error {
+	certData := secret.Data["tls.crt"]
+	if len(certData) == 0 {
+		return fmt.Errorf("error: 'tls.crt' of Secret %q is not set", secret.Name)
+	}
+
+	if !annotated {
+		_, err := out.Write(certData)
+		return err
+	}
+
+	chain, err := pki.DecodeX509CertificateChainBytes(certData)
+	if err != nil {
+		return fmt.Errorf("error when parsing 'tls.crt' of Secret %q: %s", secret.Name, err)
+	}
+	for i, cert := range chain {
+		if _, err := fmt.Fprintf(out, "# Certificate %d\n# Subject: %s\n# Issuer: %s\n", i, cert.Subject, cert.Issuer); err != nil {
+			return err
+		}
+		if err := pem.Encode(out, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportCert writes the certificates in 'tls.crt' of secret, and those in 'ca.crt' with --export-chain,
+// to the file named by o.ExportCert or to stdout if it is "-"
+func (o *Options) exportCert(secret *corev1.Secret) error {
+	if o.ExportCert == "-" {
+		return writeCertExport(o.Out, secret, o.ExportChain)
+	}
+	file, err := os.Create(o.ExportCert)
+	if err != nil {
+		return fmt.Errorf("error when creating file %q to export the certificate to: %w", o.ExportCert, err)
+	}
+	if err := writeCertExport(file, secret, o.ExportChain); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// writeCertExport writes the PEM encoded certificates in 'tls.crt' of secret to out exactly as stored,
+// once they are known to parse. If withCA, the PEM encoded CA certificate in 'ca.crt' is written after them.
+func writeCertExport(out io.Writer, secret *corev1.Secret, withCA bool) error {
+	certData := secret.Data["tls.crt"]
+	if len(certData) == 0 {
+		return fmt.Errorf("error: 'tls.crt' of Secret %q is not set", secret.Name)
+	}
+	if _, err := pki.DecodeX509CertificateChainBytes(certData); err != nil {
+		return fmt.Errorf("error when parsing 'tls.crt' of Secret %q: %s", secret.Name, err)
+	}
+	var caData []byte
+	if withCA {
+		caData = secret.Data["ca.crt"]
+		if len(caData) == 0 {
+			return fmt.Errorf("error: 'ca.crt' of Secret %q is not set", secret.Name)
+		}
+	}
+
+	if _, err := out.Write(certData); err != nil {
+		return err
+	}
+	if caData == nil {
+		return nil
+	}
+	// Keep the PEM blocks on separate lines if 'tls.crt' does not end with a newline
+	if !bytes.HasSuffix(certData, []byte("\n")) {
+		if _, err := io.WriteString(out, "\n"); err != nil {
+			return err
+		}
+	}
+	_, err := out.Write(caData)
+	return err
+}
+
 // GetResources collects all related resources of the Certificate and any errors while doing so
 // in a Data struct and returns it.
 // Returns error if error occurs when finding Certificate resource or while preparing to find other resources,
@@ -165,72 +820,279 @@ func (o *Options) GetResources(crtName string) (*Data, error) {
 		return nil, err
 	}
 
-	crt, err := o.CMClient.CertmanagerV1().Certificates(o.Namespace).Get(ctx, crtName, metav1.GetOptions{})
+	client, err := o.newResourceClient()
+	if err != nil {
+		return nil, err
+	}
+
+	getCtx, cancel := o.lookupContext(ctx)
+	defer cancel()
+	crt, err := client.getCertificate(getCtx, o.Namespace, crtName)
 	if err != nil {
 		return nil, fmt.Errorf("error when getting Certificate resource: %v", err)
 	}
 
-	crtRef, err := reference.GetReference(ctl.Scheme, crt)
+	return o.getResourcesForCertificate(ctx, clientSet, client, crt)
+}
+
+// GetResourcesForSelector gets the resources of each Certificate matching o.LabelSelector in o.Namespace,
+// or in all namespaces if o.AllNamespaces is set, sorted by namespace and name.
+// Problems getting the related resources of a Certificate are recorded in its Data, like for a single Certificate.
+func (o *Options) GetResourcesForSelector() ([]*Data, error) {
+	ctx := context.TODO()
+
+	clientSet, err := kubernetes.NewForConfig(o.RESTConfig)
 	if err != nil {
 		return nil, err
 	}
-	// If no events found, crtEvents would be nil and handled down the line in DescribeEvents
-	crtEvents, err := clientSet.CoreV1().Events(crt.Namespace).Search(ctl.Scheme, crtRef)
+
+	client, err := o.newResourceClient()
 	if err != nil {
 		return nil, err
 	}
 
-	issuer, issuerKind, issuerError := getGenericIssuer(o.CMClient, ctx, crt)
-	var issuerEvents *corev1.EventList
-	if issuer != nil {
-		issuerRef, err := reference.GetReference(ctl.Scheme, issuer)
-		if err != nil {
-			return nil, err
+	namespace := o.Namespace
+	if o.AllNamespaces {
+		namespace = metav1.NamespaceAll
+	}
+	listCtx, cancel := o.lookupContext(ctx)
+	defer cancel()
+	crts, err := client.listCertificates(listCtx, namespace, metav1.ListOptions{LabelSelector: o.LabelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("error when listing Certificate resources: %v", err)
+	}
+	sort.Slice(crts.Items, func(i, j int) bool {
+		if crts.Items[i].Namespace != crts.Items[j].Namespace {
+			return crts.Items[i].Namespace < crts.Items[j].Namespace
 		}
-		// If no events found, issuerEvents would be nil and handled down the line in DescribeEvents
-		issuerEvents, err = clientSet.CoreV1().Events(issuer.GetNamespace()).Search(ctl.Scheme, issuerRef)
+		return crts.Items[i].Name < crts.Items[j].Name
+	})
+
+	var datas []*Data
+	for i := range crts.Items {
+		crt := &crts.Items[i]
+		data, err := o.getResourcesForCertificate(ctx, clientSet, client, crt)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("error when getting the resources of Certificate %s/%s: %w", crt.Namespace, crt.Name, err)
 		}
+		datas = append(datas, data)
 	}
+	return datas, nil
+}
 
-	secret, secretErr := clientSet.CoreV1().Secrets(crt.Namespace).Get(ctx, crt.Spec.SecretName, metav1.GetOptions{})
-	if secretErr != nil {
-		secretErr = fmt.Errorf("error when finding Secret %q: %w\n", crt.Spec.SecretName, secretErr)
+// lookupContext returns a context for the lookups of the resources of a Certificate, which is cancelled
+// after o.LookupTimeout so that a hung API server does not block forever
+func (o *Options) lookupContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if o.LookupTimeout <= 0 {
+		return context.WithCancel(ctx)
 	}
-	var secretEvents *corev1.EventList
-	if secret != nil {
-		secretRef, err := reference.GetReference(ctl.Scheme, secret)
-		if err != nil {
-			return nil, err
+	return context.WithTimeout(ctx, o.LookupTimeout)
+}
+
+// searchEvents finds the events about obj, like EventInterface.Search but respecting the deadline of ctx
+func searchEvents(ctx context.Context, clientSet kubernetes.Interface, obj runtime.Object) (*corev1.EventList, error) {
+	ref, err := reference.GetReference(ctl.Scheme, obj)
+	if err != nil {
+		return nil, err
+	}
+	var refKind, refUID *string
+	if ref.Kind != "" {
+		refKind = &ref.Kind
+	}
+	if ref.UID != "" {
+		uid := string(ref.UID)
+		refUID = &uid
+	}
+	events := clientSet.CoreV1().Events(ref.Namespace)
+	fieldSelector := events.GetFieldSelector(&ref.Name, &ref.Namespace, refKind, refUID)
+	return events.List(ctx, metav1.ListOptions{FieldSelector: fieldSelector.String()})
+}
+
+// findEvents finds the events about obj with searchEvents, unless o.ShowEvents is false,
+// in which case no events are looked up and nil is returned
+func (o *Options) findEvents(ctx context.Context, clientSet kubernetes.Interface, obj runtime.Object) (*corev1.EventList, error) {
+	if !o.ShowEvents {
+		return nil, nil
+	}
+	return searchEvents(ctx, clientSet, obj)
+}
+
+// getResourcesForCertificate gets the resources related to crt, running the independent lookups concurrently
+func (o *Options) getResourcesForCertificate(ctx context.Context, clientSet kubernetes.Interface, client resourceClient, crt *cmapi.Certificate) (*Data, error) {
+	ctx, cancel := o.lookupContext(ctx)
+	defer cancel()
+
+	// The lookups for the issuer, the Secret and the CertificateRequest do not depend on each other, so they
+	// run concurrently. Each goroutine only sets its own variables, which are read after g.Wait() returns.
+	// Errors returned by a goroutine are fatal and cancel the others, errors of the lookups themselves
+	// are recorded for the status instead.
+	g, gctx := errgroup.WithContext(ctx)
+
+	// If no events found, crtEvents would be nil and handled down the line in DescribeEvents
+	var crtEvents *corev1.EventList
+	g.Go(func() (err error) {
+		crtEvents, err = o.findEvents(gctx, clientSet, crt)
+		return err
+	})
+
+	var (
+		issuer                   cmapi.GenericIssuer
+		issuerKind               string
+		issuerError              error
+		issuerEvents             *corev1.EventList
+		sameNameIssuerNamespaces []string
+		issuerCASecret           *corev1.Secret
+		issuerCASecretErr        error
+		issuerAttempts           int
+		issuerCASecretAttempts   int
+	)
+	issuerGroup, externalIssuer := issuerRefGroup(crt.Spec.IssuerRef, o.apiGroup())
+	g.Go(func() (err error) {
+		// The resources of external issuers are unknown to this command, so they are not looked up
+		if externalIssuer {
+			return nil
 		}
-		// If no events found, secretEvents would be nil and handled down the line in DescribeEvents
-		secretEvents, err = clientSet.CoreV1().Events(secret.Namespace).Search(ctl.Scheme, secretRef)
-		if err != nil {
-			return nil, err
+		issuerAttempts, issuerError = retryLookup(gctx, func() (err error) {
+			issuer, issuerKind, err = getGenericIssuer(client, gctx, crt, o.apiGroup())
+			return err
+		})
+		if issuer != nil {
+			// If no events found, issuerEvents would be nil and handled down the line in DescribeEvents
+			issuerEvents, err = o.findEvents(gctx, clientSet, issuer)
+			if err != nil {
+				return err
+			}
+		}
+
+		if o.CheckSameNameIssuers && issuerKind == "ClusterIssuer" && issuerError == nil {
+			sameNameIssuerNamespaces, err = findSameNameIssuerNamespaces(client, gctx, issuer.GetName())
+			if err != nil {
+				return err
+			}
+		}
+
+		// For CA Issuers, get the Secret holding the CA certificate that signs Certificates
+		if issuerError == nil && issuer.GetSpec().CA != nil {
+			caSecretName := issuer.GetSpec().CA.SecretName
+			caSecretNamespace := issuer.GetNamespace()
+			if issuerKind == "ClusterIssuer" {
+				caSecretNamespace = o.ClusterResourceNamespace
+			}
+			issuerCASecretAttempts, issuerCASecretErr = retryLookup(gctx, func() (err error) {
+				issuerCASecret, err = clientSet.CoreV1().Secrets(caSecretNamespace).Get(gctx, caSecretName, metav1.GetOptions{})
+				return err
+			})
+			if issuerCASecretErr != nil {
+				issuerCASecretErr = fmt.Errorf("error when finding CA Secret %q of %s %q: %w\n", caSecretName, issuerKind, issuer.GetName(), issuerCASecretErr)
+			}
+		}
+		return nil
+	})
+
+	var (
+		secret         *corev1.Secret
+		secretErr      error
+		secretEvents   *corev1.EventList
+		secretAttempts int
+	)
+	g.Go(func() (err error) {
+		secretAttempts, secretErr = retryLookup(gctx, func() (err error) {
+			secret, err = clientSet.CoreV1().Secrets(crt.Namespace).Get(gctx, crt.Spec.SecretName, metav1.GetOptions{})
+			return err
+		})
+		if secretErr != nil {
+			secretErr = fmt.Errorf("error when finding Secret %q: %w\n", crt.Spec.SecretName, secretErr)
+		}
+		if secret != nil {
+			// If no events found, secretEvents would be nil and handled down the line in DescribeEvents
+			secretEvents, err = o.findEvents(gctx, clientSet, secret)
 		}
+		return err
+	})
+
+	var (
+		nextPrivateKeySecret         *corev1.Secret
+		nextPrivateKeySecretErr      error
+		nextPrivateKeySecretAttempts int
+	)
+	if name := crt.Status.NextPrivateKeySecretName; name != nil {
+		g.Go(func() error {
+			nextPrivateKeySecretAttempts, nextPrivateKeySecretErr = retryLookup(gctx, func() (err error) {
+				nextPrivateKeySecret, err = clientSet.CoreV1().Secrets(crt.Namespace).Get(gctx, *name, metav1.GetOptions{})
+				return err
+			})
+			if nextPrivateKeySecretErr != nil {
+				nextPrivateKeySecretErr = fmt.Errorf("error when finding next private key Secret %q: %w\n", *name, nextPrivateKeySecretErr)
+			}
+			return nil
+		})
 	}
 
-	// TODO: What about timing issues? When I query condition it's not ready yet, but then looking for cr it's finished and deleted
-	// Try find the CertificateRequest that is owned by crt and has the correct revision
-	req, reqErr := findMatchingCR(o.CMClient, ctx, crt)
-	if reqErr != nil {
-		reqErr = fmt.Errorf("error when finding CertificateRequest: %w\n", reqErr)
-	} else if req == nil {
-		reqErr = errors.New("No CertificateRequest found for this Certificate\n")
+	var secretContention []string
+	if o.CheckSecretContention {
+		g.Go(func() (err error) {
+			secretContention, err = findSecretContention(client, gctx, crt)
+			return err
+		})
 	}
 
-	var reqEvents *corev1.EventList
-	if req != nil {
-		reqRef, err := reference.GetReference(ctl.Scheme, req)
-		if err != nil {
-			return nil, err
+	var (
+		req              *cmapi.CertificateRequest
+		reqErr           error
+		reqEvents        *corev1.EventList
+		reqHistory       []*cmapi.CertificateRequest
+		reqHistoryEvents map[string]*corev1.EventList
+		reqAttempts      int
+	)
+	g.Go(func() (err error) {
+		// TODO: What about timing issues? When I query condition it's not ready yet, but then looking for cr it's finished and deleted
+		// Try find the CertificateRequest that is owned by crt and has the correct revision
+		reqAttempts, reqErr = retryLookup(gctx, func() (err error) {
+			reqHistory, err = findOwnedCRs(client, gctx, crt)
+			return err
+		})
+		if reqErr == nil {
+			req, reqErr = findMatchingCR(reqHistory, crt)
 		}
-		// If no events found,  reqEvents would be nil and handled down the line in DescribeEvents
-		reqEvents, err = clientSet.CoreV1().Events(req.Namespace).Search(ctl.Scheme, reqRef)
-		if err != nil {
-			return nil, err
+		if reqErr != nil {
+			reqErr = fmt.Errorf("error when finding CertificateRequest: %w\n", reqErr)
+		} else if req == nil {
+			reqErr = errNoCertificateRequest
 		}
+		if req != nil {
+			// If no events found, reqEvents would be nil and handled down the line in DescribeEvents
+			reqEvents, err = o.findEvents(gctx, clientSet, req)
+			if err != nil {
+				return err
+			}
+		}
+
+		if o.History {
+			reqHistoryEvents = make(map[string]*corev1.EventList, len(reqHistory))
+			for _, historyReq := range reqHistory {
+				if req != nil && historyReq.Name == req.Name {
+					reqHistoryEvents[historyReq.Name] = reqEvents
+					continue
+				}
+				if reqHistoryEvents[historyReq.Name], err = o.findEvents(gctx, clientSet, historyReq); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	if o.Verbose {
+		var attempts lookupAttempts
+		attempts.add("Issuer", issuerAttempts)
+		attempts.add("CA Secret", issuerCASecretAttempts)
+		attempts.add("Secret", secretAttempts)
+		attempts.add("next private key Secret", nextPrivateKeySecretAttempts)
+		attempts.add("CertificateRequests", reqAttempts)
+		attempts.writeTo(o.ErrOut, crt.Namespace, crt.Name)
 	}
 
 	var (
@@ -243,8 +1105,11 @@ func (o *Options) GetResources(crtName string) (*Data, error) {
 	// Nothing to output about Order and Challenge if no CR or not ACME Issuer
 	if req != nil && issuer != nil && issuer.GetSpec().ACME != nil {
 		// Get Order
-		order, orderErr = findMatchingOrder(o.CMClient, ctx, req)
-		if orderErr != nil {
+		order, orderErr = FindMatchingOrder(o.CMClient, ctx, req)
+		if apierrors.IsNotFound(orderErr) {
+			// Listing a resource that is not served returns NotFound
+			orderErr = errors.New("error when finding Order: Orders are not served by the API server, are the cert-manager ACME CRDs installed?\n")
+		} else if orderErr != nil {
 			orderErr = fmt.Errorf("error when finding Order: %w\n", orderErr)
 		} else if order == nil {
 			orderErr = errors.New("No Order found for this Certificate\n")
@@ -252,7 +1117,9 @@ func (o *Options) GetResources(crtName string) (*Data, error) {
 
 		if order != nil {
 			challenges, challengeErr = findMatchingChallenges(o.CMClient, ctx, order)
-			if challengeErr != nil {
+			if apierrors.IsNotFound(challengeErr) {
+				challengeErr = errors.New("error when finding Challenges: Challenges are not served by the API server, are the cert-manager ACME CRDs installed?\n")
+			} else if challengeErr != nil {
 				challengeErr = fmt.Errorf("error when finding Challenges: %w\n", challengeErr)
 			} else if len(challenges) == 0 {
 				challengeErr = errors.New("No Challenges found for this Certificate\n")
@@ -260,36 +1127,111 @@ func (o *Options) GetResources(crtName string) (*Data, error) {
 		}
 	}
 
+	var challengeDNSLookups map[string][]DNSLookup
+	if o.CheckDNS && len(challenges) > 0 {
+		timeout := dnsutil.DNSTimeout
+		if o.Timeout > 0 {
+			timeout = o.Timeout
+		}
+		nameservers := dnsutil.RecursiveNameservers
+		if len(o.DNSNameservers) > 0 {
+			nameservers = withDefaultDNSPort(o.DNSNameservers)
+		}
+		challengeDNSLookups = checkDNS01Challenges(challenges, nameservers, newLookupTXT(timeout))
+	}
+
+	var probe *ProbeResult
+	if o.Probe != "" {
+		result := probeTLS(o.Probe, o.Timeout)
+		probe = &result
+	}
+
 	return &Data{
-		Certificate:  crt,
-		CrtEvents:    crtEvents,
-		Issuer:       issuer,
-		IssuerKind:   issuerKind,
-		IssuerError:  issuerError,
-		IssuerEvents: issuerEvents,
-		Secret:       secret,
-		SecretError:  secretErr,
-		SecretEvents: secretEvents,
-		Req:          req,
-		ReqError:     reqErr,
-		ReqEvents:    reqEvents,
-		Order:        order,
-		OrderError:   orderErr,
-		Challenges:   challenges,
-		ChallengeErr: challengeErr,
+		Certificate:               crt,
+		CrtEvents:                 crtEvents,
+		Issuer:                    issuer,
+		IssuerKind:                issuerKind,
+		IssuerGroup:               issuerGroup,
+		ExternalIssuer:            externalIssuer,
+		IssuerError:               issuerError,
+		IssuerEvents:              issuerEvents,
+		SameNameIssuerNamespaces:  sameNameIssuerNamespaces,
+		IssuerCASecret:            issuerCASecret,
+		IssuerCASecretError:       issuerCASecretErr,
+		SecretContention:          secretContention,
+		Secret:                    secret,
+		SecretError:               secretErr,
+		NextPrivateKeySecret:      nextPrivateKeySecret,
+		NextPrivateKeySecretError: nextPrivateKeySecretErr,
+		SecretEvents:              secretEvents,
+		Req:                       req,
+		ReqError:                  reqErr,
+		ReqEvents:                 reqEvents,
+		ReqHistory:                reqHistory,
+		ReqHistoryEvents:          reqHistoryEvents,
+		Order:                     order,
+		OrderError:                orderErr,
+		Challenges:                challenges,
+		ChallengeErr:              challengeErr,
+		ChallengeDNSLookups:       challengeDNSLookups,
+		Probe:                     probe,
 	}, nil
 }
 
+// apiGroup returns the API group that Certificates and their related cert-manager resources are read from
+func (o *Options) apiGroup() string {
+	if o.APIGroup == "" {
+		return cmapi.SchemeGroupVersion.Group
+	}
+	return o.APIGroup
+}
+
+// newResourceClient returns a resourceClient reading from the API group of o.
+// For any group other than cert-manager.io, the resources are read from the version of the group
+// preferred by the API server, which is first checked to serve Certificates.
+func (o *Options) newResourceClient() (resourceClient, error) {
+	group := o.apiGroup()
+	if group == cmapi.SchemeGroupVersion.Group {
+		return typedResourceClient{cmClient: o.CMClient}, nil
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(o.RESTConfig)
+	if err != nil {
+		return nil, err
+	}
+	version, err := preferredAPIGroupVersion(discoveryClient, group)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(o.RESTConfig)
+	if err != nil {
+		return nil, err
+	}
+	return dynamicResourceClient{client: dynamicClient, group: group, version: version}, nil
+}
+
 // StatusFromResources takes in a Data struct and returns a CertificateStatus built using
-// the information in data.
-func StatusFromResources(data *Data) *CertificateStatus {
-	return newCertificateStatusFromCert(data.Certificate).
+// the information in data. Durations relative to the current time are computed using clock.
+func StatusFromResources(data *Data, clock clock.Clock) *CertificateStatus {
+	return newCertificateStatusFromCert(data.Certificate, clock).
 		withEvents(data.CrtEvents).
-		withGenericIssuer(data.Issuer, data.IssuerKind, data.IssuerEvents, data.IssuerError).
-		withSecret(data.Secret, data.SecretEvents, data.SecretError).
-		withCR(data.Req, data.ReqEvents, data.ReqError).
+		withGenericIssuer(data.Issuer, data.IssuerKind, data.IssuerEvents, data.IssuerError, clock).
+		withIssuerRef(data.Certificate.Spec.IssuerRef, data.IssuerGroup, data.ExternalIssuer).
+		withSameNameIssuers(data.SameNameIssuerNamespaces).
+		withIssuerCA(data.IssuerCASecret, data.IssuerCASecretError, clock).
+		withSecret(data.Secret, data.SecretEvents, data.SecretError, clock).
+		withSecretOwner(data.Secret, data.Certificate).
+		withSecretContention(data.SecretContention).
+		withNextPrivateKey(data.NextPrivateKeySecret, data.NextPrivateKeySecretError).
+		withSpecDrift().
+		withRenewalState(clock).
+		withCR(data.Req, data.ReqEvents, data.ReqError, clock).
+		withDNSNameSources().
+		withCRHistory(data.ReqHistory, data.ReqHistoryEvents, clock).
 		withOrder(data.Order, data.OrderError).
-		withChallenges(data.Challenges, data.ChallengeErr)
+		withChallenges(data.Challenges, data.ChallengeErr).
+		withDNSChecks(data.ChallengeDNSLookups).
+		withProbe(data.Probe)
 }
 
 // formatStringSlice takes in a string slice and formats the contents of the slice
@@ -311,16 +1253,40 @@ func formatTimeString(t *metav1.Time) string {
 	return t.Time.Format(time.RFC3339)
 }
 
-// findMatchingCR tries to find a CertificateRequest that is owned by crt and has the correct revision annotated from reqs.
-// If none found returns nil
-// If one found returns the CR
-// If multiple found or error occurs when listing CRs, returns error
-func findMatchingCR(cmClient cmclient.Interface, ctx context.Context, crt *cmapi.Certificate) (*cmapi.CertificateRequest, error) {
-	reqs, err := cmClient.CertmanagerV1().CertificateRequests(crt.Namespace).List(ctx, metav1.ListOptions{})
+// findOwnedCRs returns the CertificateRequests that are owned by crt and have a revision annotated,
+// sorted by revision from newest to oldest
+func findOwnedCRs(client resourceClient, ctx context.Context, crt *cmapi.Certificate) ([]*cmapi.CertificateRequest, error) {
+	reqs, err := client.listCertificateRequests(ctx, crt.Namespace)
 	if err != nil {
 		return nil, fmt.Errorf("error when listing CertificateRequest resources: %w", err)
 	}
 
+	var owned []*cmapi.CertificateRequest
+	for _, req := range reqs.Items {
+		if _, ok := certificateRequestRevision(&req); ok && predicate.ResourceOwnedBy(crt)(&req) {
+			owned = append(owned, req.DeepCopy())
+		}
+	}
+	sort.SliceStable(owned, func(i, j int) bool {
+		revisionI, _ := certificateRequestRevision(owned[i])
+		revisionJ, _ := certificateRequestRevision(owned[j])
+		return revisionI > revisionJ
+	})
+	return owned, nil
+}
+
+// certificateRequestRevision returns the revision annotated on req, and false if it has none or it is not a number
+func certificateRequestRevision(req *cmapi.CertificateRequest) (int, bool) {
+	revision, err := strconv.Atoi(req.Annotations[cmapi.CertificateRequestRevisionAnnotationKey])
+	return revision, err == nil
+}
+
+// findMatchingCR tries to find a CertificateRequest that has the correct revision annotated from reqs,
+// which are owned by crt.
+// If none found returns nil
+// If one found returns the CR
+// If multiple found or error occurs when listing CRs, returns error
+func findMatchingCR(reqs []*cmapi.CertificateRequest, crt *cmapi.Certificate) (*cmapi.CertificateRequest, error) {
 	possibleMatches := []*cmapi.CertificateRequest{}
 
 	// CertificateRequest revisions begin from 1.
@@ -330,10 +1296,9 @@ func findMatchingCR(cmClient cmclient.Interface, ctx context.Context, crt *cmapi
 	if crt.Status.Revision != nil {
 		nextRevision = *crt.Status.Revision + 1
 	}
-	for _, req := range reqs.Items {
-		if predicate.CertificateRequestRevision(nextRevision)(&req) &&
-			predicate.ResourceOwnedBy(crt)(&req) {
-			possibleMatches = append(possibleMatches, req.DeepCopy())
+	for _, req := range reqs {
+		if predicate.CertificateRequestRevision(nextRevision)(req) {
+			possibleMatches = append(possibleMatches, req)
 		}
 	}
 
@@ -346,11 +1311,11 @@ func findMatchingCR(cmClient cmclient.Interface, ctx context.Context, crt *cmapi
 	}
 }
 
-// findMatchingOrder tries to find an Order that is owned by req.
+// FindMatchingOrder tries to find an Order that is owned by req.
 // If none found returns nil
 // If one found returns the Order
 // If multiple found or error occurs when listing Orders, returns error
-func findMatchingOrder(cmClient cmclient.Interface, ctx context.Context, req *cmapi.CertificateRequest) (*cmacme.Order, error) {
+func FindMatchingOrder(cmClient cmclient.Interface, ctx context.Context, req *cmapi.CertificateRequest) (*cmacme.Order, error) {
 	orders, err := cmClient.AcmeV1beta1().Orders(req.Namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, err
@@ -372,30 +1337,74 @@ func findMatchingOrder(cmClient cmclient.Interface, ctx context.Context, req *cm
 	}
 }
 
-func getGenericIssuer(cmClient cmclient.Interface, ctx context.Context, crt *cmapi.Certificate) (cmapi.GenericIssuer, string, error) {
+// getGenericIssuer gets the Issuer or ClusterIssuer referenced by crt, which must be of apiGroup.
+func getGenericIssuer(client resourceClient, ctx context.Context, crt *cmapi.Certificate, apiGroup string) (cmapi.GenericIssuer, string, error) {
 	issuerKind := crt.Spec.IssuerRef.Kind
 	if issuerKind == "" {
 		issuerKind = "Issuer"
 	}
 
-	if crt.Spec.IssuerRef.Group != "cert-manager.io" && crt.Spec.IssuerRef.Group != "" {
-		// TODO: Support Issuers/ClusterIssuers from other groups as well
-		return nil, "", fmt.Errorf("The %s %q is not of the group cert-manager.io, this command currently does not support third party issuers.\nTo get more information about %q, try 'kubectl describe'\n",
-			issuerKind, crt.Spec.IssuerRef.Name, crt.Spec.IssuerRef.Name)
+	if _, external := issuerRefGroup(crt.Spec.IssuerRef, apiGroup); external {
+		return nil, "", fmt.Errorf("The %s %q is not of the group %s, this command currently does not support third party issuers.\nTo get more information about %q, try 'kubectl describe'\n",
+			issuerKind, crt.Spec.IssuerRef.Name, apiGroup, crt.Spec.IssuerRef.Name)
 	} else if issuerKind == "Issuer" {
-		issuer, issuerErr := cmClient.CertmanagerV1().Issuers(crt.Namespace).Get(ctx, crt.Spec.IssuerRef.Name, metav1.GetOptions{})
+		issuer, issuerErr := client.getIssuer(ctx, crt.Namespace, crt.Spec.IssuerRef.Name)
 		if issuerErr != nil {
-			issuerErr = fmt.Errorf("error when getting Issuer: %v\n", issuerErr)
+			// Return an untyped nil, a nil *Issuer would not compare equal to nil as a GenericIssuer
+			return nil, issuerKind, fmt.Errorf("error when getting Issuer: %w\n", issuerErr)
 		}
-		return issuer, issuerKind, issuerErr
+		return issuer, issuerKind, nil
 	} else {
 		// ClusterIssuer
-		clusterIssuer, issuerErr := cmClient.CertmanagerV1().ClusterIssuers().Get(ctx, crt.Spec.IssuerRef.Name, metav1.GetOptions{})
+		clusterIssuer, issuerErr := client.getClusterIssuer(ctx, crt.Spec.IssuerRef.Name)
 		if issuerErr != nil {
-			issuerErr = fmt.Errorf("error when getting ClusterIssuer: %v\n", issuerErr)
+			return nil, issuerKind, fmt.Errorf("error when getting ClusterIssuer: %w\n", issuerErr)
+		}
+		return clusterIssuer, issuerKind, nil
+	}
+}
+
+// issuerRefGroup returns the API group of the issuer referenced by ref, which defaults to apiGroup,
+// and whether it is an external issuer, i.e. not an Issuer or ClusterIssuer of apiGroup
+func issuerRefGroup(ref cmmeta.ObjectReference, apiGroup string) (string, bool) {
+	if ref.Group == "" {
+		return apiGroup, false
+	}
+	return ref.Group, ref.Group != apiGroup
+}
+
+// findSameNameIssuerNamespaces returns the namespaces of all Issuers named name, across all namespaces.
+func findSameNameIssuerNamespaces(client resourceClient, ctx context.Context, name string) ([]string, error) {
+	issuers, err := client.listIssuers(ctx, metav1.NamespaceAll, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", name).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error when listing Issuers with name %q: %w", name, err)
+	}
+
+	var namespaces []string
+	for _, issuer := range issuers.Items {
+		namespaces = append(namespaces, issuer.Namespace)
+	}
+	return namespaces, nil
+}
+
+// findSecretContention returns the names of the other Certificates in the namespace of crt that have
+// the same Secret Name as crt, sorted. cert-manager would keep reissuing the Secret for each of them in turn.
+func findSecretContention(client resourceClient, ctx context.Context, crt *cmapi.Certificate) ([]string, error) {
+	crts, err := client.listCertificates(ctx, crt.Namespace, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error when listing Certificates in namespace %q: %w", crt.Namespace, err)
+	}
+
+	var names []string
+	for _, other := range crts.Items {
+		if other.Name != crt.Name && other.Spec.SecretName == crt.Spec.SecretName {
+			names = append(names, other.Name)
 		}
-		return clusterIssuer, issuerKind, issuerErr
 	}
+	sort.Strings(names)
+	return names, nil
 }
 
 // findMatchingChallenges tries to find Challenges that are owned by order.
@@ -17,20 +17,55 @@ limitations under the License.
 package certificate
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
 	"errors"
+	"fmt"
+	"io/ioutil"
 	"math/big"
+	"net"
+	"net/http/httptest"
+	"net/url"
+	"os"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	fakekubernetes "k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/utils/clock"
+	fakeclock "k8s.io/utils/clock/testing"
+	"software.sslmate.com/src/go-pkcs12"
 
+	cmacmev1 "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1beta1"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
 	"github.com/jetstack/cert-manager/test/unit/gen"
 )
 
@@ -68,6 +103,8 @@ func TestFormatStringSlice(t *testing.T) {
 }
 
 func TestCRInfoString(t *testing.T) {
+	now := time.Date(2020, 7, 1, 2, 13, 0, 0, time.UTC)
+	lastTransition := metav1.NewTime(time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC))
 	tests := map[string]struct {
 		cr        *cmapi.CertificateRequest
 		err       error
@@ -102,13 +139,44 @@ func TestCRInfoString(t *testing.T) {
   Conditions:
     Ready: True, Reason: , Message: example
   Events:  <none>
+`,
+		},
+		"CR with multiple conditions indents each of them": {
+			cr: &cmapi.CertificateRequest{
+				Status: cmapi.CertificateRequestStatus{
+					Conditions: []cmapi.CertificateRequestCondition{
+						{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionFalse, Reason: "Pending", Message: "example"},
+						{Type: cmapi.CertificateRequestConditionInvalidRequest, Status: cmmeta.ConditionFalse},
+					}}},
+			expOutput: `CertificateRequest:
+  Name: 
+  Namespace: 
+  Conditions:
+    Ready: False, Reason: Pending, Message: example
+    InvalidRequest: False, Reason: , Message: 
+  Events:  <none>
+`,
+		},
+		"CR with a Last Transition Time prints it with the age of the condition": {
+			cr: &cmapi.CertificateRequest{
+				Status: cmapi.CertificateRequestStatus{
+					Conditions: []cmapi.CertificateRequestCondition{
+						{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionFalse, Reason: "Failed", Message: "example",
+							LastTransitionTime: &lastTransition},
+					}}},
+			expOutput: `CertificateRequest:
+  Name: 
+  Namespace: 
+  Conditions:
+    Ready: False, Reason: Failed, Message: example, Last Transition: 2020-07-01T00:00:00Z (for 133m)
+  Events:  <none>
 `,
 		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			actualOutput := (&CertificateStatus{}).withCR(test.cr, nil, test.err).CRStatus.String()
+			actualOutput := (&CertificateStatus{}).withCR(test.cr, nil, test.err, fakeclock.NewFakeClock(now)).CRStatus.String()
 			if strings.TrimSpace(actualOutput) != strings.TrimSpace(test.expOutput) {
 				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, actualOutput)
 			}
@@ -116,6 +184,103 @@ func TestCRInfoString(t *testing.T) {
 	}
 }
 
+func TestCRStatusStringDoesNotPrint(t *testing.T) {
+	events := &corev1.EventList{Items: []corev1.Event{{Type: "Normal", Reason: "Issued", Message: "example"}}}
+	crStatus := (&CertificateStatus{}).withCR(&cmapi.CertificateRequest{}, events, nil, fakeclock.NewFakeClock(time.Now())).CRStatus
+
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	output := crStatus.String()
+	os.Stdout = stdout
+	w.Close()
+
+	printed, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, string(printed))
+	assert.Contains(t, output, "example")
+}
+
+func TestNewRequestedStatus(t *testing.T) {
+	template, err := pki.GenerateCSR(gen.Certificate("test-crt",
+		gen.SetCertificateCommonName("example.com"),
+		gen.SetCertificateDNSNames("example.com", "www.example.com"),
+		gen.SetCertificateIPs("10.0.0.1"),
+		gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sk, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrDER, err := pki.EncodeCSR(template, sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+
+	tests := map[string]struct {
+		req          *cmapi.CertificateRequest
+		expRequested *RequestedStatus
+		expOutput    string
+	}{
+		"CR without a CSR": {
+			req:          gen.CertificateRequest("test-req"),
+			expRequested: nil,
+		},
+		"CR with an invalid CSR": {
+			req: gen.CertificateRequest("test-req", gen.SetCertificateRequestCSR([]byte("not a CSR"))),
+			expRequested: &RequestedStatus{
+				Error: errors.New("error when decoding the certificate signing request: error decoding certificate request PEM block\n")},
+			expOutput: "  error when decoding the certificate signing request: error decoding certificate request PEM block\n",
+		},
+		"CR with a CSR and default usages": {
+			req: gen.CertificateRequest("test-req", gen.SetCertificateRequestCSR(csrPEM)),
+			expRequested: &RequestedStatus{CommonName: "example.com", DNSNames: []string{"example.com", "www.example.com"},
+				IPAddresses: []string{"10.0.0.1"}},
+			expOutput: `  Requested:
+    Common Name: example.com
+    DNS Names: example.com, www.example.com
+    IP Addresses: 10.0.0.1
+    URIs: 
+    Email Addresses: 
+    Usages: digital signature, key encipherment (default)
+    Is CA: false
+`,
+		},
+		"CA CR with a CSR and usages": {
+			req: gen.CertificateRequest("test-req", gen.SetCertificateRequestCSR(csrPEM), gen.SetCertificateRequestIsCA(true),
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageCertSign, cmapi.UsageCRLSign)),
+			expRequested: &RequestedStatus{CommonName: "example.com", DNSNames: []string{"example.com", "www.example.com"},
+				IPAddresses: []string{"10.0.0.1"}, Usages: []cmapi.KeyUsage{cmapi.UsageCertSign, cmapi.UsageCRLSign}, IsCA: true},
+			expOutput: `  Requested:
+    Common Name: example.com
+    DNS Names: example.com, www.example.com
+    IP Addresses: 10.0.0.1
+    URIs: 
+    Email Addresses: 
+    Usages: cert sign, crl sign
+    Is CA: true
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			requested := newRequestedStatus(test.req)
+			assert.Equal(t, test.expRequested, requested)
+			if requested != nil {
+				assert.Equal(t, test.expOutput, requested.String())
+			}
+		})
+	}
+}
+
 func TestKeyUsageToString(t *testing.T) {
 	tests := map[string]struct {
 		usage     x509.KeyUsage
@@ -137,6 +302,38 @@ func TestKeyUsageToString(t *testing.T) {
 			usage:     x509.KeyUsageDigitalSignature | x509.KeyUsageDataEncipherment | x509.KeyUsageContentCommitment,
 			expOutput: "Digital Signature, Content Commitment, Data Encipherment",
 		},
+		"key usage Key Agreement": {
+			usage:     x509.KeyUsageKeyAgreement,
+			expOutput: "Key Agreement",
+		},
+		"key usage Encipher Only": {
+			usage:     x509.KeyUsageEncipherOnly,
+			expOutput: "Encipher Only",
+		},
+		"key usage Decipher Only": {
+			usage:     x509.KeyUsageDecipherOnly,
+			expOutput: "Decipher Only",
+		},
+		"key usage Key Agreement with Encipher Only and Decipher Only": {
+			usage:     x509.KeyUsageKeyAgreement | x509.KeyUsageEncipherOnly | x509.KeyUsageDecipherOnly,
+			expOutput: "Key Agreement, Encipher Only, Decipher Only",
+		},
+		"key usage of a TLS server certificate": {
+			usage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+			expOutput: "Digital Signature, Key Encipherment",
+		},
+		"key usage of a CA certificate": {
+			usage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+			expOutput: "Digital Signature, Cert Sign, CRL Sign",
+		},
+		"key usage of an ECDH certificate": {
+			usage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyAgreement,
+			expOutput: "Digital Signature, Key Agreement",
+		},
+		"unknown bits are ignored": {
+			usage:     x509.KeyUsageCertSign | x509.KeyUsage(512),
+			expOutput: "Cert Sign",
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -168,9 +365,15 @@ func TestExtKeyUsageToString(t *testing.T) {
 		},
 		"undefined extended key usage": {
 			extUsage:       []x509.ExtKeyUsage{x509.ExtKeyUsage(42)},
-			expOutput:      "",
+			expOutput:      "Unknown (42)",
+			expError:       true,
+			expErrorOutput: "encountered unknown Extended Usages with codes 42",
+		},
+		"mix of known and undefined extended key usages": {
+			extUsage:       []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsage(42), x509.ExtKeyUsageClientAuth, x509.ExtKeyUsage(-1)},
+			expOutput:      "Server Authentication, Unknown (42), Client Authentication, Unknown (-1)",
 			expError:       true,
-			expErrorOutput: "error when converting Extended Usages to string: encountered unknown Extended Usage with code 42",
+			expErrorOutput: "encountered unknown Extended Usages with codes 42, -1",
 		},
 	}
 	for name, test := range tests {
@@ -191,191 +394,961 @@ func TestExtKeyUsageToString(t *testing.T) {
 	}
 }
 
-func TestStatusFromResources(t *testing.T) {
-	timestamp, err := time.Parse(time.RFC3339, "2020-09-16T09:26:18Z")
+func TestFormatISO8601Duration(t *testing.T) {
+	tests := map[string]struct {
+		duration  time.Duration
+		expOutput string
+	}{
+		"zero duration": {
+			duration:  0,
+			expOutput: "PT0S",
+		},
+		"whole days": {
+			duration:  12 * 24 * time.Hour,
+			expOutput: "P12D",
+		},
+		"days, hours, minutes and seconds": {
+			duration:  2*24*time.Hour + 3*time.Hour + 4*time.Minute + 5*time.Second,
+			expOutput: "P2DT3H4M5S",
+		},
+		"fractions of seconds are truncated": {
+			duration:  90*time.Minute + 500*time.Millisecond,
+			expOutput: "PT1H30M",
+		},
+		"negative duration": {
+			duration:  -(3*24*time.Hour + 2*time.Hour),
+			expOutput: "-P3DT2H",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actualOutput := formatISO8601Duration(test.duration); actualOutput != test.expOutput {
+				t.Errorf("Unexpected output; expected: %q, actual: %q", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestNewCertificateStatusRevision(t *testing.T) {
+	issuing := cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}
+	notIssuing := cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionFalse}
+	tests := map[string]struct {
+		crt       *cmapi.Certificate
+		expOutput string
+	}{
+		"not issued yet": {
+			crt:       gen.Certificate("test"),
+			expOutput: "<none>",
+		},
+		"first issuance in progress": {
+			crt:       gen.Certificate("test", gen.SetCertificateStatusCondition(issuing)),
+			expOutput: "<none> (issuance in progress)",
+		},
+		"issued": {
+			crt:       gen.Certificate("test", gen.SetCertificateRevision(3), gen.SetCertificateStatusCondition(notIssuing)),
+			expOutput: "3",
+		},
+		"reissuance in progress": {
+			crt:       gen.Certificate("test", gen.SetCertificateRevision(3), gen.SetCertificateStatusCondition(issuing)),
+			expOutput: "3 (reissuance in progress)",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := newCertificateStatusFromCert(test.crt, fakeclock.NewFakeClock(time.Now()))
+			if actualOutput := formatRevision(status.Revision, status.Issuing); actualOutput != test.expOutput {
+				t.Errorf("Unexpected output; expected: %q, actual: %q", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestHasCTPoisonExtension(t *testing.T) {
+	sk, err := pki.GenerateECPrivateKey(pki.ECCurve256)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	tlsCrt := []byte(`-----BEGIN CERTIFICATE-----
-MIICyTCCAbGgAwIBAgIRAOL4jtyULBSEYyGdqQn9YzowDQYJKoZIhvcNAQELBQAw
-DzENMAsGA1UEAxMEdGVzdDAeFw0yMDA3MzAxNjExNDNaFw0yMDEwMjgxNjExNDNa
-MA8xDTALBgNVBAMTBHRlc3QwggEiMA0GCSqGSIb3DQEBAQUAA4IBDwAwggEKAoIB
-AQDdfNmjh5ag7f6U1hj1OAx/dEN9kQzPsSlBMXGb/Ho4k5iegrFd6w8JkYdCthFv
-lfg3bIhw5tCKaw1o57HnWKBKKGt7XpeIu1mEcv8pveMIPO7TZ4+oElgX880NfJmL
-DkjEcctEo/+FurudO1aEbNfbNWpzudYKj7gGtYshBytqaYt4/APqWARJBFCYVVys
-wexZ0fLi5cBD8H1bQ1Ec3OCr5Mrq9thAGkj+rVlgYR0AZVGa9+SCOj27t6YCmyzR
-AJSEQ35v58Zfxp5tNyYd6wcAswJ9YipnUXvwahF95PNlRmMhp3Eo15m9FxehcVXU
-BOfxykMwZN7onMhuHiiwiB+NAgMBAAGjIDAeMA4GA1UdDwEB/wQEAwIFoDAMBgNV
-HRMBAf8EAjAAMA0GCSqGSIb3DQEBCwUAA4IBAQALrnldWjTBTvV5WKapUHUG0rhA
-vp2Cf+5FsPw8vKScXp4L+wKGdPOjhHz6NOiw5wu8A0HxlVUFawRpagkjFkeTL78O
-9ghBHLiqn9xNPIKC6ID3WpnN5terwQxQeO/M54sVMslUWCcZm9Pu4Eb//2e6wEdu
-eMmpfeISQmCsBC1CTmpxUjeUg5DEQ0X1TQykXq+bG2iso6RYPxZTFTHJFzXiDYEc
-/X7H+bOmpo/dMrXapwfvp2gD+BEq96iVpf/DBzGYNs/657LAHJ4YtxtAZCa1CK9G
-MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
------END CERTIFICATE-----`)
+	tests := map[string]struct {
+		extensions []pkix.Extension
+		expPoison  bool
+	}{
+		"certificate without extra extensions": {
+			extensions: nil,
+			expPoison:  false,
+		},
+		"precertificate with poison extension": {
+			extensions: []pkix.Extension{{Id: oidExtensionCTPoison, Critical: true, Value: []byte{0x05, 0x00}}},
+			expPoison:  true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			template := &x509.Certificate{
+				SerialNumber:    big.NewInt(1),
+				Subject:         pkix.Name{CommonName: "test"},
+				NotBefore:       time.Now(),
+				NotAfter:        time.Now().Add(time.Hour),
+				ExtraExtensions: test.extensions,
+			}
+			derBytes, err := x509.CreateCertificate(rand.Reader, template, template, sk.Public(), sk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cert, err := x509.ParseCertificate(derBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual := hasCTPoisonExtension(cert); actual != test.expPoison {
+				t.Errorf("Unexpected result; expected: %t, actual: %t", test.expPoison, actual)
+			}
+		})
+	}
+}
 
-	serialNum, _ := new(big.Int).SetString("301696114246524167282555582613204853562", 10)
-	ns := "ns1"
-	dummyEventList := &corev1.EventList{
-		Items: []corev1.Event{{
-			Type:    "type",
-			Reason:  "reason",
-			Message: "message",
-		}},
+// generateCertPEM creates an x509 certificate from template, signed by parent with parentKey,
+// or self-signed if parent is nil, and returns it PEM encoded along with its private key
+func generateCertPEM(t *testing.T, template, parent *x509.Certificate, parentKey crypto.Signer) ([]byte, crypto.Signer) {
+	sk, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
 	}
+	if parent == nil {
+		parent, parentKey = template, sk
+	}
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, parent, sk.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes}), sk
+}
+
+func TestNewCAValidityStatus(t *testing.T) {
+	caNotBefore := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	caNotAfter := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             caNotBefore,
+		NotAfter:              caNotAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caPEM, caKey := generateCertPEM(t, caTemplate, nil, nil)
+	caCert, err := pki.DecodeX509CertificateBytes(caPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leaf := func(notBefore, notAfter time.Time) []byte {
+		leafPEM, _ := generateCertPEM(t, &x509.Certificate{
+			SerialNumber: big.NewInt(2),
+			Subject:      pkix.Name{CommonName: "leaf"},
+			NotBefore:    notBefore,
+			NotAfter:     notAfter,
+		}, caCert, caKey)
+		return leafPEM
+	}
+	withinCA := leaf(caNotBefore.Add(time.Hour), caNotAfter.Add(-time.Hour))
+	exceedsCA := leaf(caNotBefore.Add(-time.Hour), caNotAfter.Add(time.Hour))
 
 	tests := map[string]struct {
-		inputData *Data
-		expOutput *CertificateStatus
+		data      map[string][]byte
+		expStatus *CAValidityStatus
 	}{
-		"Correct information extracted from Certificate resource": {
-			inputData: &Data{
-				Certificate: gen.Certificate("test-crt",
-					gen.SetCertificateNamespace(ns),
-					gen.SetCertificateNotAfter(metav1.Time{Time: timestamp}),
-					gen.SetCertificateNotBefore(metav1.Time{Time: timestamp}),
-					gen.SetCertificateRenewalTIme(metav1.Time{Time: timestamp}),
-					gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady,
-						Status: cmmeta.ConditionTrue, Message: "Certificate is up to date and has not expired"}),
-					gen.SetCertificateDNSNames("example.com"),
-				),
-				CrtEvents: dummyEventList,
-			},
-			expOutput: &CertificateStatus{
-				Name:         "test-crt",
-				Namespace:    ns,
-				CreationTime: metav1.Time{},
-				Conditions: []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady,
-					Status: cmmeta.ConditionTrue, Message: "Certificate is up to date and has not expired"}},
-				DNSNames:    []string{"example.com"},
-				Events:      dummyEventList,
-				NotBefore:   &metav1.Time{Time: timestamp},
-				NotAfter:    &metav1.Time{Time: timestamp},
-				RenewalTime: &metav1.Time{Time: timestamp},
-			},
+		"no CA certificate in the Secret": {
+			data:      map[string][]byte{"tls.crt": withinCA},
+			expStatus: nil,
 		},
-		"Issuer correctly with Kind Issuer": {
-			inputData: &Data{
-				Certificate: gen.Certificate("test-crt",
-					gen.SetCertificateNamespace(ns)),
-				Issuer:       gen.Issuer("test-issuer"),
-				IssuerKind:   "Issuer",
-				IssuerError:  nil,
-				IssuerEvents: dummyEventList,
-			},
-			expOutput: &CertificateStatus{
-				Name:         "test-crt",
-				Namespace:    ns,
-				CreationTime: metav1.Time{},
-				IssuerStatus: &IssuerStatus{
-					Name:   "test-issuer",
-					Kind:   "Issuer",
-					Events: dummyEventList,
-				},
-			},
+		"certificate within the validity of the CA from ca.crt": {
+			data: map[string][]byte{"tls.crt": withinCA, "ca.crt": caPEM},
+			expStatus: &CAValidityStatus{Source: "ca.crt", NotBefore: caNotBefore, NotAfter: caNotAfter,
+				TrustedUntil: caNotAfter.Add(-time.Hour)},
 		},
-		"Issuer correctly with Kind ClusterIssuer": {
-			inputData: &Data{
-				Certificate: gen.Certificate("test-crt",
-					gen.SetCertificateNamespace(ns)),
-				Issuer:       gen.Issuer("test-clusterissuer"),
-				IssuerKind:   "ClusterIssuer",
-				IssuerError:  nil,
-				IssuerEvents: dummyEventList,
-			},
-			expOutput: &CertificateStatus{
-				Name:         "test-crt",
-				Namespace:    ns,
-				CreationTime: metav1.Time{},
-				IssuerStatus: &IssuerStatus{
-					Name:   "test-clusterissuer",
-					Kind:   "ClusterIssuer",
-					Events: dummyEventList,
-				},
-			},
+		"certificate outside the validity of the CA from the chain": {
+			data: map[string][]byte{"tls.crt": append(exceedsCA, caPEM...)},
+			expStatus: &CAValidityStatus{Source: "tls.crt", NotBefore: caNotBefore, NotAfter: caNotAfter,
+				NotBeforePrecedesCA: true, NotAfterExceedsCA: true, TrustedUntil: caNotAfter},
 		},
-		"Correct information extracted from Secret resource": {
-			inputData: &Data{
-				Certificate: gen.Certificate("test-crt",
-					gen.SetCertificateNamespace(ns)),
-				Secret: gen.Secret("existing-tls-secret",
-					gen.SetSecretNamespace(ns),
-					gen.SetSecretData(map[string][]byte{"tls.crt": tlsCrt})),
-				SecretError:  nil,
-				SecretEvents: dummyEventList,
-			},
-			expOutput: &CertificateStatus{
-				Name:         "test-crt",
-				Namespace:    ns,
-				CreationTime: metav1.Time{},
-				SecretStatus: &SecretStatus{
-					Error:              nil,
-					Name:               "existing-tls-secret",
-					IssuerCountry:      nil,
-					IssuerOrganisation: nil,
-					IssuerCommonName:   "test",
-					KeyUsage:           x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
-					ExtKeyUsage:        nil,
-					PublicKeyAlgorithm: x509.RSA,
-					SignatureAlgorithm: x509.SHA256WithRSA,
-					SubjectKeyId:       nil,
-					AuthorityKeyId:     nil,
-					SerialNumber:       serialNum,
-					Events:             dummyEventList,
-				},
-			},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cert, err := pki.DecodeX509CertificateBytes(test.data["tls.crt"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := newCAValidityStatus(cert, gen.Secret("test-secret", gen.SetSecretData(test.data)))
+			assert.Equal(t, test.expStatus, got)
+		})
+	}
+}
+
+func TestWithChainVerification(t *testing.T) {
+	now := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	caTemplate := func(cn string) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: cn},
+			NotBefore:             now.Add(-time.Hour),
+			NotAfter:              now.Add(time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign,
+		}
+	}
+	rootPEM, rootKey := generateCertPEM(t, caTemplate("root"), nil, nil)
+	rootCert, err := pki.DecodeX509CertificateBytes(rootPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	intermediatePEM, intermediateKey := generateCertPEM(t, caTemplate("intermediate"), rootCert, rootKey)
+	intermediateCert, err := pki.DecodeX509CertificateBytes(intermediatePEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafPEM, _ := generateCertPEM(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+	}, intermediateCert, intermediateKey)
+	otherRootPEM, _ := generateCertPEM(t, caTemplate("other root"), nil, nil)
+
+	tests := map[string]struct {
+		secretStatus *SecretStatus
+		data         map[string][]byte
+		expVerified  bool
+		expLength    int
+		expError     string
+	}{
+		"nothing to verify against without ca.crt": {
+			secretStatus: &SecretStatus{Name: "test-tls"},
+			data:         map[string][]byte{"tls.crt": append(leafPEM, intermediatePEM...)},
 		},
-		"Correct information extracted from CR resource": {
-			inputData: &Data{
-				Certificate: gen.Certificate("test-crt",
-					gen.SetCertificateNamespace(ns)),
-				Req: gen.CertificateRequest("test-req",
-					gen.SetCertificateRequestNamespace(ns),
-					gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionFalse, Reason: "Pending", Message: "Waiting on certificate issuance from order default/example-order: \"pending\""})),
-				ReqError:  nil,
-				ReqEvents: dummyEventList,
-			},
-			expOutput: &CertificateStatus{
-				Name:         "test-crt",
-				Namespace:    ns,
-				CreationTime: metav1.Time{},
-				CRStatus: &CRStatus{
-					Error:      nil,
-					Name:       "test-req",
-					Namespace:  ns,
-					Conditions: []cmapi.CertificateRequestCondition{{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionFalse, Reason: "Pending", Message: "Waiting on certificate issuance from order default/example-order: \"pending\""}},
-					Events:     dummyEventList,
+		"chain with the intermediate verified against the root in ca.crt": {
+			secretStatus: &SecretStatus{Name: "test-tls"},
+			data:         map[string][]byte{"tls.crt": append(leafPEM, intermediatePEM...), "ca.crt": rootPEM},
+			expVerified:  true,
+			expLength:    3,
+		},
+		"leaf verified against the intermediate in ca.crt": {
+			secretStatus: &SecretStatus{Name: "test-tls"},
+			data:         map[string][]byte{"tls.crt": leafPEM, "ca.crt": intermediatePEM},
+			expVerified:  true,
+			expLength:    2,
+		},
+		"chain missing the intermediate": {
+			secretStatus: &SecretStatus{Name: "test-tls"},
+			data:         map[string][]byte{"tls.crt": leafPEM, "ca.crt": rootPEM},
+			expError:     "x509: certificate signed by unknown authority",
+		},
+		"chain of another root": {
+			secretStatus: &SecretStatus{Name: "test-tls"},
+			data:         map[string][]byte{"tls.crt": append(leafPEM, intermediatePEM...), "ca.crt": otherRootPEM},
+			expError:     "x509: certificate signed by unknown authority",
+		},
+		"invalid ca.crt": {
+			secretStatus: &SecretStatus{Name: "test-tls"},
+			data:         map[string][]byte{"tls.crt": leafPEM, "ca.crt": []byte("not a certificate")},
+			expError:     `error when parsing 'ca.crt' of Secret "test-tls": error decoding certificate PEM block`,
+		},
+		"Secret that could not be parsed is not verified": {
+			secretStatus: &SecretStatus{Error: errors.New("error when parsing 'tls.crt'")},
+			data:         map[string][]byte{"tls.crt": leafPEM, "ca.crt": rootPEM},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test-tls"}, Data: test.data}
+			status := (&CertificateStatus{SecretStatus: test.secretStatus}).
+				withChainVerification(secret, false, fakeclock.NewFakeClock(now))
+			assert.Equal(t, test.expVerified, status.SecretStatus.ChainVerified)
+			assert.Equal(t, test.expLength, status.SecretStatus.ChainLength)
+			// The message of x509 verification errors differs between Go versions, so only its start is compared
+			if test.expError == "" {
+				assert.Empty(t, status.SecretStatus.ChainError)
+			} else {
+				assert.True(t, strings.HasPrefix(status.SecretStatus.ChainError, test.expError),
+					"expected error starting with %q, got %q", test.expError, status.SecretStatus.ChainError)
+			}
+		})
+	}
+}
+
+func TestNewCACertificateStatus(t *testing.T) {
+	caNotAfter := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	caPEM, _ := generateCertPEM(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(0x1234),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:              caNotAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+
+	tests := map[string]struct {
+		data      map[string][]byte
+		expStatus *CACertificateStatus
+		expOutput string
+	}{
+		"ca.crt not set": {
+			data:      map[string][]byte{},
+			expStatus: nil,
+		},
+		"ca.crt set": {
+			data:      map[string][]byte{"ca.crt": caPEM},
+			expStatus: &CACertificateStatus{SubjectCommonName: "ca", IssuerCommonName: "ca", NotAfter: caNotAfter, SerialNumber: big.NewInt(0x1234)},
+			expOutput: `  CA Certificate:
+    Subject Common Name: ca
+    Issuer Common Name: ca
+    Not After: 2021-01-01T00:00:00Z
+    Serial Number: 12:34
+`,
+		},
+		"ca.crt that does not parse": {
+			data:      map[string][]byte{"ca.crt": []byte("not a certificate")},
+			expStatus: &CACertificateStatus{Error: errors.New("error when parsing 'ca.crt' of Secret \"test-secret\": error decoding certificate PEM block\n")},
+			expOutput: `  CA Certificate: error when parsing 'ca.crt' of Secret "test-secret": error decoding certificate PEM block
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := newCACertificateStatus(gen.Secret("test-secret", gen.SetSecretData(test.data)))
+			assert.Equal(t, test.expStatus, got)
+			if got != nil {
+				assert.Equal(t, test.expOutput, got.String())
+			}
+		})
+	}
+}
+
+func TestKeyMatchesCert(t *testing.T) {
+	rsaKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	selfSigned := func(key crypto.Signer) *x509.Certificate {
+		template := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "test"},
+			NotBefore: time.Now(), NotAfter: time.Now().Add(time.Hour)}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cert
+	}
+	keyPEM := func(key crypto.Signer) []byte {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	}
+
+	matches, mismatches := true, false
+	tests := map[string]struct {
+		cert       *x509.Certificate
+		key        []byte
+		expMatches *bool
+		expErr     bool
+	}{
+		"tls.key not set": {
+			cert:       selfSigned(ecKey),
+			expMatches: nil,
+		},
+		"RSA key matches": {
+			cert:       selfSigned(rsaKey),
+			key:        pki.EncodePKCS1PrivateKey(rsaKey),
+			expMatches: &matches,
+		},
+		"ECDSA key matches": {
+			cert:       selfSigned(ecKey),
+			key:        keyPEM(ecKey),
+			expMatches: &matches,
+		},
+		"Ed25519 key matches": {
+			cert:       selfSigned(edKey),
+			key:        keyPEM(edKey),
+			expMatches: &matches,
+		},
+		"key of another certificate": {
+			cert:       selfSigned(rsaKey),
+			key:        keyPEM(ecKey),
+			expMatches: &mismatches,
+		},
+		"tls.key that does not parse": {
+			cert:   selfSigned(ecKey),
+			key:    []byte("not a key"),
+			expErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := map[string][]byte{}
+			if test.key != nil {
+				data["tls.key"] = test.key
+			}
+			got, err := keyMatchesCert(gen.Secret("test-secret", gen.SetSecretData(data)), test.cert)
+			if test.expErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expMatches, got)
+		})
+	}
+}
+
+func TestNewChainStatus(t *testing.T) {
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:              time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caPEM, caKey := generateCertPEM(t, caTemplate, nil, nil)
+	caCert, err := pki.DecodeX509CertificateBytes(caPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafPEM, _ := generateCertPEM(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		NotBefore:    caTemplate.NotBefore,
+		NotAfter:     caTemplate.NotAfter,
+	}, caCert, caKey)
+	leafCert, err := pki.DecodeX509CertificateBytes(leafPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		chain         []*x509.Certificate
+		expChain      []ChainCertificateStatus
+		expOutOfOrder bool
+	}{
+		"leaf only": {
+			chain:    []*x509.Certificate{leafCert},
+			expChain: nil,
+		},
+		"leaf followed by its CA": {
+			chain:    []*x509.Certificate{leafCert, caCert},
+			expChain: []ChainCertificateStatus{{Subject: "CN=leaf", Issuer: "CN=ca"}, {Subject: "CN=ca", Issuer: "CN=ca"}},
+		},
+		"CA followed by the leaf": {
+			chain:         []*x509.Certificate{caCert, leafCert},
+			expChain:      []ChainCertificateStatus{{Subject: "CN=ca", Issuer: "CN=ca"}, {Subject: "CN=leaf", Issuer: "CN=ca"}},
+			expOutOfOrder: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			chain, outOfOrder := newChainStatus(test.chain)
+			assert.Equal(t, test.expChain, chain)
+			assert.Equal(t, test.expOutOfOrder, outOfOrder)
+		})
+	}
+}
+
+func TestWriteChain(t *testing.T) {
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caPEM, caKey := generateCertPEM(t, caTemplate, nil, nil)
+	caCert, err := pki.DecodeX509CertificateBytes(caPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafPEM, _ := generateCertPEM(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}, caCert, caKey)
+	chainPEM := append(append([]byte{}, leafPEM...), caPEM...)
+
+	tests := map[string]struct {
+		data      map[string][]byte
+		annotated bool
+		expOutput string
+		expErr    bool
+	}{
+		"plain chain is written exactly as stored": {
+			data:      map[string][]byte{"tls.crt": chainPEM},
+			expOutput: string(chainPEM),
+		},
+		"annotated chain has a header before each certificate": {
+			data:      map[string][]byte{"tls.crt": chainPEM},
+			annotated: true,
+			expOutput: "# Certificate 0\n# Subject: CN=leaf\n# Issuer: CN=ca\n" + string(leafPEM) +
+				"# Certificate 1\n# Subject: CN=ca\n# Issuer: CN=ca\n" + string(caPEM),
+		},
+		"missing tls.crt throws error": {
+			data:   map[string][]byte{},
+			expErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := writeChain(&out, gen.Secret("test-secret", gen.SetSecretData(test.data)), test.annotated)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, expected error: %t, got: %v", test.expErr, err)
+			}
+			if out.String() != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, out.String())
+			}
+		})
+	}
+}
+
+func TestWriteCertExport(t *testing.T) {
+	caPEM, caKey := generateCertPEM(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+	caCert, err := pki.DecodeX509CertificateBytes(caPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafPEM, _ := generateCertPEM(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}, caCert, caKey)
+	// A comment and no trailing newline, as may be stored by hand, are kept as they are
+	storedPEM := append([]byte("# leaf\n"), bytes.TrimSuffix(leafPEM, []byte("\n"))...)
+
+	tests := map[string]struct {
+		data      map[string][]byte
+		withCA    bool
+		expOutput string
+		expErr    bool
+	}{
+		"certificate is written exactly as stored": {
+			data:      map[string][]byte{"tls.crt": storedPEM, "ca.crt": caPEM},
+			expOutput: string(storedPEM),
+		},
+		"chain is the certificate followed by the CA certificate": {
+			data:      map[string][]byte{"tls.crt": storedPEM, "ca.crt": caPEM},
+			withCA:    true,
+			expOutput: string(storedPEM) + "\n" + string(caPEM),
+		},
+		"missing ca.crt throws error for the chain": {
+			data:   map[string][]byte{"tls.crt": leafPEM},
+			withCA: true,
+			expErr: true,
+		},
+		"tls.crt that does not parse throws error": {
+			data:   map[string][]byte{"tls.crt": []byte("not a certificate")},
+			expErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := writeCertExport(&out, gen.Secret("test-secret", gen.SetSecretData(test.data)), test.withCA)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, expected error: %t, got: %v", test.expErr, err)
+			}
+			assert.Equal(t, test.expOutput, out.String())
+		})
+	}
+}
+
+func TestWithNextPrivateKey(t *testing.T) {
+	ecKey, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKeyPEM, err := pki.EncodePrivateKey(ecKey, cmapi.PKCS8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nextKeySecret := func(keyPEM []byte) *corev1.Secret {
+		return gen.Secret("test-crt-next-key", gen.SetSecretData(map[string][]byte{corev1.TLSPrivateKeyKey: keyPEM}))
+	}
+
+	tests := map[string]struct {
+		secret    *corev1.Secret
+		err       error
+		issuing   bool
+		expStatus *NextPrivateKeyStatus
+		expOutput string
+	}{
+		"no next private key Secret": {
+			issuing: true,
+		},
+		"next private key is printed while issuing": {
+			secret:    nextKeySecret(ecKeyPEM),
+			issuing:   true,
+			expStatus: &NextPrivateKeyStatus{SecretName: "test-crt-next-key", KeyAlgorithm: x509.ECDSA, KeyBits: 256, KeyCurve: "P-256"},
+			expOutput: "Next Private Key:\n  Secret: test-crt-next-key\n  Key: ECDSA (P-256)\n",
+		},
+		"next private key is not printed when not issuing": {
+			secret:    nextKeySecret(ecKeyPEM),
+			expStatus: &NextPrivateKeyStatus{SecretName: "test-crt-next-key", KeyAlgorithm: x509.ECDSA, KeyBits: 256, KeyCurve: "P-256"},
+		},
+		"error when finding the next private key Secret": {
+			err:       errors.New("error when finding next private key Secret \"test-crt-next-key\": not found\n"),
+			issuing:   true,
+			expStatus: &NextPrivateKeyStatus{Error: errors.New("error when finding next private key Secret \"test-crt-next-key\": not found\n")},
+			expOutput: "Next Private Key:\n  error when finding next private key Secret \"test-crt-next-key\": not found\n",
+		},
+		"next private key that does not parse is a warning": {
+			secret:  nextKeySecret([]byte("not a key")),
+			issuing: true,
+			expStatus: &NextPrivateKeyStatus{
+				Error: errors.New("error when parsing 'tls.key' of next private key Secret \"test-crt-next-key\": error decoding private key PEM block\n")},
+			expOutput: "Next Private Key:\n  error when parsing 'tls.key' of next private key Secret \"test-crt-next-key\": error decoding private key PEM block\n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := (&CertificateStatus{Name: "test-crt", Namespace: "ns1", Issuing: test.issuing,
+				IssuerStatus: &IssuerStatus{Name: "test-issuer", Kind: "Issuer"},
+				SecretStatus: &SecretStatus{Error: errors.New("error when finding Secret")},
+				CRStatus:     &CRStatus{Name: "test-crt-1"}}).withNextPrivateKey(test.secret, test.err)
+			assert.Equal(t, test.expStatus, status.NextPrivateKeyStatus)
+			output := writerToString(status)
+			if test.expOutput != "" {
+				assert.Contains(t, output, test.expOutput)
+			} else {
+				assert.NotContains(t, output, "Next Private Key")
+			}
+		})
+	}
+}
+
+func TestWithIssuerCA(t *testing.T) {
+	now := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	caNotAfter := time.Date(2020, 6, 11, 0, 0, 0, 0, time.UTC)
+	caPEM, _ := generateCertPEM(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              caNotAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}, nil, nil)
+
+	tests := map[string]struct {
+		caSecret  *corev1.Secret
+		err       error
+		expStatus *IssuerCAStatus
+		expOutput string
+	}{
+		"CA certificate near expiry": {
+			caSecret:  gen.Secret("ca-key-pair", gen.SetSecretData(map[string][]byte{"tls.crt": caPEM})),
+			expStatus: &IssuerCAStatus{SecretName: "ca-key-pair", NotAfter: caNotAfter, ExpiresIn: 10 * 24 * time.Hour},
+			expOutput: `  CA Certificate:
+    Secret: ca-key-pair
+    Not After: 2020-06-11T00:00:00Z
+    Expires In: 10d
+`,
+		},
+		"CA Secret without certificate": {
+			caSecret:  gen.Secret("ca-key-pair"),
+			expStatus: &IssuerCAStatus{Error: errors.New("error when parsing 'tls.crt' of CA Secret \"ca-key-pair\": error decoding certificate PEM block\n")},
+			expOutput: `  error when parsing 'tls.crt' of CA Secret "ca-key-pair": error decoding certificate PEM block
+`,
+		},
+		"CA Secret not found": {
+			err:       errors.New("dummy error\n"),
+			expStatus: &IssuerCAStatus{Error: errors.New("dummy error\n")},
+			expOutput: `  dummy error
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := (&CertificateStatus{IssuerStatus: &IssuerStatus{Name: "ca-issuer"}}).
+				withIssuerCA(test.caSecret, test.err, fakeclock.NewFakeClock(now))
+			assert.Equal(t, test.expStatus, status.IssuerStatus.CAStatus)
+			if actualOutput := status.IssuerStatus.CAStatus.String(); actualOutput != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestStatusFromResources(t *testing.T) {
+	timestamp, err := time.Parse(time.RFC3339, "2020-09-16T09:26:18Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tlsCrt := []byte(`-----BEGIN CERTIFICATE-----
+MIICyTCCAbGgAwIBAgIRAOL4jtyULBSEYyGdqQn9YzowDQYJKoZIhvcNAQELBQAw
+DzENMAsGA1UEAxMEdGVzdDAeFw0yMDA3MzAxNjExNDNaFw0yMDEwMjgxNjExNDNa
+MA8xDTALBgNVBAMTBHRlc3QwggEiMA0GCSqGSIb3DQEBAQUAA4IBDwAwggEKAoIB
+AQDdfNmjh5ag7f6U1hj1OAx/dEN9kQzPsSlBMXGb/Ho4k5iegrFd6w8JkYdCthFv
+lfg3bIhw5tCKaw1o57HnWKBKKGt7XpeIu1mEcv8pveMIPO7TZ4+oElgX880NfJmL
+DkjEcctEo/+FurudO1aEbNfbNWpzudYKj7gGtYshBytqaYt4/APqWARJBFCYVVys
+wexZ0fLi5cBD8H1bQ1Ec3OCr5Mrq9thAGkj+rVlgYR0AZVGa9+SCOj27t6YCmyzR
+AJSEQ35v58Zfxp5tNyYd6wcAswJ9YipnUXvwahF95PNlRmMhp3Eo15m9FxehcVXU
+BOfxykMwZN7onMhuHiiwiB+NAgMBAAGjIDAeMA4GA1UdDwEB/wQEAwIFoDAMBgNV
+HRMBAf8EAjAAMA0GCSqGSIb3DQEBCwUAA4IBAQALrnldWjTBTvV5WKapUHUG0rhA
+vp2Cf+5FsPw8vKScXp4L+wKGdPOjhHz6NOiw5wu8A0HxlVUFawRpagkjFkeTL78O
+9ghBHLiqn9xNPIKC6ID3WpnN5terwQxQeO/M54sVMslUWCcZm9Pu4Eb//2e6wEdu
+eMmpfeISQmCsBC1CTmpxUjeUg5DEQ0X1TQykXq+bG2iso6RYPxZTFTHJFzXiDYEc
+/X7H+bOmpo/dMrXapwfvp2gD+BEq96iVpf/DBzGYNs/657LAHJ4YtxtAZCa1CK9G
+MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
+-----END CERTIFICATE-----`)
+
+	serialNum, _ := new(big.Int).SetString("301696114246524167282555582613204853562", 10)
+	x509Cert, err := pki.DecodeX509CertificateBytes(tlsCrt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	specMatchesIssued := true
+	ns := "ns1"
+	dummyEventList := &corev1.EventList{
+		Items: []corev1.Event{{
+			Type:    "type",
+			Reason:  "reason",
+			Message: "message",
+		}},
+	}
+
+	tests := map[string]struct {
+		inputData *Data
+		expOutput *CertificateStatus
+	}{
+		"Correct information extracted from Certificate resource": {
+			inputData: &Data{
+				Certificate: gen.Certificate("test-crt",
+					gen.SetCertificateNamespace(ns),
+					gen.SetCertificateNotAfter(metav1.Time{Time: timestamp}),
+					gen.SetCertificateNotBefore(metav1.Time{Time: timestamp}),
+					gen.SetCertificateRenewalTIme(metav1.Time{Time: timestamp}),
+					gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady,
+						Status: cmmeta.ConditionTrue, Message: "Certificate is up to date and has not expired"}),
+					gen.SetCertificateDNSNames("example.com"),
+				),
+				CrtEvents: dummyEventList,
+			},
+			expOutput: &CertificateStatus{
+				Name:              "test-crt",
+				Namespace:         ns,
+				CreationTime:      metav1.Time{},
+				RequestedDuration: newDurationStatus(cmapi.DefaultCertificateDuration),
+				ObservedAt:        timestamp.Add(-24 * time.Hour),
+				Conditions: []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady,
+					Status: cmmeta.ConditionTrue, Message: "Certificate is up to date and has not expired"}},
+				DNSNames:    []string{"example.com"},
+				Events:      dummyEventList,
+				NotBefore:   &metav1.Time{Time: timestamp},
+				NotAfter:    &metav1.Time{Time: timestamp},
+				RenewalTime: &metav1.Time{Time: timestamp},
+				Validity: &ValidityStatus{
+					ExpiresIn: &DurationStatus{Seconds: 86400, ISO8601: "P1D"},
+					Lifetime:  &DurationStatus{Seconds: 0, ISO8601: "PT0S"},
+					RenewalIn: &DurationStatus{Seconds: 86400, ISO8601: "P1D"},
 				},
+				RenewalState: renewalStateOK,
 			},
 		},
-		"Correct information extracted from Order resource": {
+		"Issuer correctly with Kind Issuer": {
 			inputData: &Data{
 				Certificate: gen.Certificate("test-crt",
 					gen.SetCertificateNamespace(ns)),
-				Order: &cmacme.Order{
-					TypeMeta:   metav1.TypeMeta{},
-					ObjectMeta: metav1.ObjectMeta{Name: "example-order", Namespace: ns},
-					Spec:       cmacme.OrderSpec{Request: []byte("dummyCSR"), DNSNames: []string{"www.example.com"}},
-					Status:     cmacme.OrderStatus{},
-				},
-				OrderError: nil,
+				Issuer:       gen.Issuer("test-issuer"),
+				IssuerKind:   "Issuer",
+				IssuerError:  nil,
+				IssuerEvents: dummyEventList,
 			},
 			expOutput: &CertificateStatus{
-				Name:         "test-crt",
-				Namespace:    ns,
-				CreationTime: metav1.Time{},
-				OrderStatus: &OrderStatus{
-					Error:          nil,
-					Name:           "example-order",
-					State:          "",
-					Reason:         "",
-					Authorizations: nil,
-					FailureTime:    nil,
+				Name:              "test-crt",
+				Namespace:         ns,
+				CreationTime:      metav1.Time{},
+				RequestedDuration: newDurationStatus(cmapi.DefaultCertificateDuration),
+				ObservedAt:        timestamp.Add(-24 * time.Hour),
+				IssuerStatus: &IssuerStatus{
+					Name:       "test-issuer",
+					Kind:       "Issuer",
+					ObservedAt: timestamp.Add(-24 * time.Hour),
+					Events:     dummyEventList,
 				},
 			},
 		},
-		"Correct information extracted from Challenge resources": {
+		"Issuer correctly with Kind ClusterIssuer": {
+			inputData: &Data{
+				Certificate: gen.Certificate("test-crt",
+					gen.SetCertificateNamespace(ns)),
+				Issuer:       gen.Issuer("test-clusterissuer"),
+				IssuerKind:   "ClusterIssuer",
+				IssuerError:  nil,
+				IssuerEvents: dummyEventList,
+			},
+			expOutput: &CertificateStatus{
+				Name:              "test-crt",
+				Namespace:         ns,
+				CreationTime:      metav1.Time{},
+				RequestedDuration: newDurationStatus(cmapi.DefaultCertificateDuration),
+				ObservedAt:        timestamp.Add(-24 * time.Hour),
+				IssuerStatus: &IssuerStatus{
+					Name:       "test-clusterissuer",
+					Kind:       "ClusterIssuer",
+					ObservedAt: timestamp.Add(-24 * time.Hour),
+					Events:     dummyEventList,
+				},
+			},
+		},
+		"Namespaces of Issuers with the same name as the ClusterIssuer": {
+			inputData: &Data{
+				Certificate: gen.Certificate("test-crt",
+					gen.SetCertificateNamespace(ns)),
+				Issuer:                   gen.ClusterIssuer("test-clusterissuer"),
+				IssuerKind:               "ClusterIssuer",
+				SameNameIssuerNamespaces: []string{"ns2", "ns3"},
+			},
+			expOutput: &CertificateStatus{
+				Name:              "test-crt",
+				Namespace:         ns,
+				CreationTime:      metav1.Time{},
+				RequestedDuration: newDurationStatus(cmapi.DefaultCertificateDuration),
+				ObservedAt:        timestamp.Add(-24 * time.Hour),
+				IssuerStatus: &IssuerStatus{
+					Name:                     "test-clusterissuer",
+					Kind:                     "ClusterIssuer",
+					ObservedAt:               timestamp.Add(-24 * time.Hour),
+					SameNameIssuerNamespaces: []string{"ns2", "ns3"},
+				},
+			},
+		},
+		"Correct information extracted from Secret resource": {
+			inputData: &Data{
+				Certificate: gen.Certificate("test-crt",
+					gen.SetCertificateNamespace(ns)),
+				Secret: gen.Secret("existing-tls-secret",
+					gen.SetSecretNamespace(ns),
+					gen.SetSecretData(map[string][]byte{"tls.crt": tlsCrt})),
+				SecretError:  nil,
+				SecretEvents: dummyEventList,
+			},
+			expOutput: &CertificateStatus{
+				Name:              "test-crt",
+				Namespace:         ns,
+				CreationTime:      metav1.Time{},
+				RequestedDuration: newDurationStatus(cmapi.DefaultCertificateDuration),
+				ObservedAt:        timestamp.Add(-24 * time.Hour),
+				ActualDuration:    newDurationStatus(90 * 24 * time.Hour),
+				SpecMatchesIssued: &specMatchesIssued,
+				IssuedDNSNames:    []string{},
+				SecretStatus: &SecretStatus{
+					Error:                 nil,
+					Name:                  "existing-tls-secret",
+					IssuerCountry:         nil,
+					IssuerOrganisation:    nil,
+					IssuerCommonName:      "test",
+					SubjectCommonName:     "test",
+					KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+					ExtKeyUsage:           nil,
+					PublicKeyAlgorithm:    x509.RSA,
+					KeyBits:               2048,
+					SignatureAlgorithm:    x509.SHA256WithRSA,
+					SubjectKeyId:          nil,
+					AuthorityKeyId:        nil,
+					SerialNumber:          serialNum,
+					Version:               3,
+					FingerprintSHA256:     "1C:25:ED:E3:19:6A:B2:DB:E4:39:C8:A1:D0:02:86:AC:89:68:65:C3:65:59:4D:12:52:FE:FB:04:D8:01:14:CD",
+					FingerprintSHA1:       "2F:54:49:27:28:B1:59:D8:CF:A9:BD:CD:8C:BE:6E:AC:A8:A1:83:F4",
+					BasicConstraintsValid: true,
+					NotBefore:             time.Date(2020, 7, 30, 16, 11, 43, 0, time.UTC),
+					NotAfter:              time.Date(2020, 10, 28, 16, 11, 43, 0, time.UTC),
+					ExpiresIn:             time.Date(2020, 10, 28, 16, 11, 43, 0, time.UTC).Sub(timestamp.Add(-24 * time.Hour)),
+					ObservedAt:            timestamp.Add(-24 * time.Hour),
+					Events:                dummyEventList,
+					x509Cert:              x509Cert,
+				},
+			},
+		},
+		"Correct information extracted from CR resource": {
+			inputData: &Data{
+				Certificate: gen.Certificate("test-crt",
+					gen.SetCertificateNamespace(ns)),
+				Req: gen.CertificateRequest("test-req",
+					gen.SetCertificateRequestNamespace(ns),
+					gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionFalse, Reason: "Pending", Message: "Waiting on certificate issuance from order default/example-order: \"pending\""})),
+				ReqError:  nil,
+				ReqEvents: dummyEventList,
+			},
+			expOutput: &CertificateStatus{
+				Name:              "test-crt",
+				Namespace:         ns,
+				CreationTime:      metav1.Time{},
+				RequestedDuration: newDurationStatus(cmapi.DefaultCertificateDuration),
+				ObservedAt:        timestamp.Add(-24 * time.Hour),
+				CRStatus: &CRStatus{
+					Error:      nil,
+					Name:       "test-req",
+					Namespace:  ns,
+					Conditions: []cmapi.CertificateRequestCondition{{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionFalse, Reason: "Pending", Message: "Waiting on certificate issuance from order default/example-order: \"pending\""}},
+					ObservedAt: timestamp.Add(-24 * time.Hour),
+					Events:     dummyEventList,
+				},
+			},
+		},
+		"Correct information extracted from Order resource": {
+			inputData: &Data{
+				Certificate: gen.Certificate("test-crt",
+					gen.SetCertificateNamespace(ns)),
+				Order: &cmacme.Order{
+					TypeMeta:   metav1.TypeMeta{},
+					ObjectMeta: metav1.ObjectMeta{Name: "example-order", Namespace: ns},
+					Spec:       cmacme.OrderSpec{Request: []byte("dummyCSR"), DNSNames: []string{"www.example.com"}},
+					Status:     cmacme.OrderStatus{},
+				},
+				OrderError: nil,
+			},
+			expOutput: &CertificateStatus{
+				Name:              "test-crt",
+				Namespace:         ns,
+				CreationTime:      metav1.Time{},
+				RequestedDuration: newDurationStatus(cmapi.DefaultCertificateDuration),
+				ObservedAt:        timestamp.Add(-24 * time.Hour),
+				OrderStatus: &OrderStatus{
+					Error:          nil,
+					Name:           "example-order",
+					State:          "",
+					Reason:         "",
+					Authorizations: nil,
+					FailureTime:    nil,
+				},
+			},
+		},
+		"Correct information extracted from Challenge resources": {
 			inputData: &Data{
 				Certificate: gen.Certificate("test-crt",
 					gen.SetCertificateNamespace(ns)),
@@ -414,9 +1387,11 @@ MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 				ChallengeErr: nil,
 			},
 			expOutput: &CertificateStatus{
-				Name:         "test-crt",
-				Namespace:    ns,
-				CreationTime: metav1.Time{},
+				Name:              "test-crt",
+				Namespace:         ns,
+				CreationTime:      metav1.Time{},
+				RequestedDuration: newDurationStatus(cmapi.DefaultCertificateDuration),
+				ObservedAt:        timestamp.Add(-24 * time.Hour),
 				ChallengeStatusList: &ChallengeStatusList{
 					ChallengeStatuses: []*ChallengeStatus{
 						{
@@ -469,6 +1444,8 @@ MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 				Name:                "test-crt",
 				Namespace:           ns,
 				CreationTime:        metav1.Time{},
+				RequestedDuration:   newDurationStatus(cmapi.DefaultCertificateDuration),
+				ObservedAt:          timestamp.Add(-24 * time.Hour),
 				IssuerStatus:        &IssuerStatus{Error: errors.New("dummy error")},
 				SecretStatus:        &SecretStatus{Error: errors.New("dummy error")},
 				CRStatus:            &CRStatus{Error: errors.New("dummy error")},
@@ -479,8 +1456,2919 @@ MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			got := StatusFromResources(test.inputData)
+			got := StatusFromResources(test.inputData, fakeclock.NewFakeClock(timestamp.Add(-24*time.Hour)))
 			assert.Equal(t, test.expOutput, got)
 		})
 	}
 }
+
+func TestCompactString(t *testing.T) {
+	notAfter := &metav1.Time{Time: time.Date(2020, 6, 11, 0, 0, 0, 0, time.UTC)}
+	renewalTime := &metav1.Time{Time: time.Date(2020, 5, 31, 0, 0, 0, 0, time.UTC)}
+
+	tests := map[string]struct {
+		status    *CertificateStatus
+		expOutput string
+	}{
+		"ready Certificate with warnings": {
+			status: &CertificateStatus{
+				Name:      "test-crt",
+				Namespace: "ns1",
+				Conditions: []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady,
+					Status: cmmeta.ConditionTrue, Reason: "Ready", Message: "Certificate is up to date and has not expired"}},
+				DNSNames:    []string{"example.com", "www.example.com"},
+				NotAfter:    notAfter,
+				RenewalTime: renewalTime,
+				Validity: &ValidityStatus{
+					ExpiresIn: newDurationStatus(10 * 24 * time.Hour),
+					RenewalIn: newDurationStatus(-24 * time.Hour),
+				},
+				IssuerStatus: &IssuerStatus{Name: "ca-issuer", Kind: "Issuer",
+					CAStatus: &IssuerCAStatus{SecretName: "ca-key-pair", ExpiresIn: 10 * 24 * time.Hour}},
+				SecretStatus: &SecretStatus{IsPrecertificate: true},
+			},
+			expOutput: `Certificate: ns1/test-crt
+Ready: True, Reason: Ready, Message: Certificate is up to date and has not expired
+Issuer: ca-issuer (Issuer)
+DNS Names: 2
+Not After: 2020-06-11T00:00:00Z (in 10d)
+Renewal Time: 2020-05-31T00:00:00Z (24h ago)
+Warnings: 2
+`,
+		},
+		"Certificate without status and missing Issuer": {
+			status: &CertificateStatus{
+				Name:         "test-crt",
+				Namespace:    "ns1",
+				IssuerStatus: &IssuerStatus{Error: errors.New("error when getting Issuer: not found\n")},
+			},
+			expOutput: `Certificate: ns1/test-crt
+Ready: Unknown, no Ready condition set
+Issuer: error when getting Issuer: not found
+DNS Names: 0
+Not After: <none>
+Renewal Time: <none>
+Warnings: 0
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actualOutput := test.status.CompactString(); actualOutput != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestWithSignaturePolicy(t *testing.T) {
+	tests := map[string]struct {
+		algo        x509.SignatureAlgorithm
+		minStrength string
+		expPolicy   *SignaturePolicyStatus
+		expOutput   string
+	}{
+		"no policy configured": {
+			algo: x509.SHA1WithRSA,
+		},
+		"SHA-256 meets sha256": {
+			algo:        x509.SHA256WithRSA,
+			minStrength: "sha256",
+			expPolicy:   &SignaturePolicyStatus{Strength: "sha256", MinStrength: "sha256", Compliant: true},
+			expOutput:   "  Signature Strength: sha256, meets the minimum strength sha256 required by policy\n",
+		},
+		"SHA-1 below sha256": {
+			algo:        x509.ECDSAWithSHA1,
+			minStrength: "sha256",
+			expPolicy:   &SignaturePolicyStatus{Strength: "weak", MinStrength: "sha256"},
+			expOutput:   "  Signature Strength: weak, below the minimum strength sha256 required by policy\n",
+		},
+		"SHA-256 below sha384": {
+			algo:        x509.ECDSAWithSHA256,
+			minStrength: "sha384",
+			expPolicy:   &SignaturePolicyStatus{Strength: "sha256", MinStrength: "sha384"},
+			expOutput:   "  Signature Strength: sha256, below the minimum strength sha384 required by policy\n",
+		},
+		"unknown algorithm never meets a policy": {
+			algo:        x509.UnknownSignatureAlgorithm,
+			minStrength: "weak",
+			expPolicy:   &SignaturePolicyStatus{Strength: "unknown", MinStrength: "weak"},
+			expOutput:   "  Signature Strength: unknown, below the minimum strength weak required by policy\n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := (&CertificateStatus{SecretStatus: &SecretStatus{SignatureAlgorithm: test.algo}}).
+				withSignaturePolicy(test.minStrength)
+			assert.Equal(t, test.expPolicy, status.SecretStatus.SignaturePolicy)
+			if test.expPolicy == nil {
+				return
+			}
+			if actualOutput := status.SecretStatus.SignaturePolicy.String(); actualOutput != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestPreferredAPIGroupVersion(t *testing.T) {
+	tests := map[string]struct {
+		resources  []*metav1.APIResourceList
+		expVersion string
+		expErr     string
+	}{
+		"group served with certificates": {
+			resources: []*metav1.APIResourceList{{GroupVersion: "certmanager.example.com/v1",
+				APIResources: []metav1.APIResource{{Name: "issuers"}, {Name: "certificates"}}}},
+			expVersion: "v1",
+		},
+		"group served with certificates in another version than v1": {
+			resources: []*metav1.APIResourceList{{GroupVersion: "certmanager.example.com/v1alpha1",
+				APIResources: []metav1.APIResource{{Name: "certificates"}}}},
+			expVersion: "v1alpha1",
+		},
+		"group served without certificates": {
+			resources: []*metav1.APIResourceList{{GroupVersion: "certmanager.example.com/v1",
+				APIResources: []metav1.APIResource{{Name: "issuers"}}}},
+			expErr: `API group version "certmanager.example.com/v1" is served but has no certificates resource`,
+		},
+		"group not served": {
+			resources: []*metav1.APIResourceList{{GroupVersion: "cert-manager.io/v1",
+				APIResources: []metav1.APIResource{{Name: "certificates"}}}},
+			expErr: `API group "certmanager.example.com" is not served`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			discoveryClient := &fakediscovery.FakeDiscovery{Fake: &coretesting.Fake{Resources: test.resources}}
+			version, err := preferredAPIGroupVersion(discoveryClient, "certmanager.example.com")
+			if test.expErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expVersion, version)
+			} else {
+				assert.EqualError(t, err, test.expErr)
+			}
+		})
+	}
+}
+
+func TestNowFlag(t *testing.T) {
+	newOptions := func(now string) *Options {
+		o := NewOptions(genericclioptions.IOStreams{})
+		o.Now, o.Color, o.FingerprintAlgorithm, o.EventType = now, colorModeAuto, fingerprintAlgorithmSHA256, eventTypeAll
+		return o
+	}
+
+	o := newOptions("")
+	if err := o.Validate([]string{"test-crt"}); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, clock.RealClock{}, o.clock())
+
+	o = newOptions("not a time")
+	assert.EqualError(t, o.Validate([]string{"test-crt"}),
+		`invalid --now "not a time", must be an RFC3339 time: parsing time "not a time" as "2006-01-02T15:04:05Z07:00": cannot parse "not a time" as "2006"`)
+
+	o = newOptions("2020-09-16T09:26:18Z")
+	o.Watch, o.Interval = true, time.Second
+	assert.EqualError(t, o.Validate([]string{"test-crt"}), "cannot specify --now in conjunction with --watch")
+
+	o = newOptions("2020-09-16T09:26:18Z")
+	if err := o.Validate([]string{"test-crt"}); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2020, 9, 16, 9, 26, 18, 0, time.UTC)
+	assert.Equal(t, now, o.clock().Now())
+	assert.Equal(t, time.Hour, o.clock().Since(now.Add(-time.Hour)))
+
+	// Every relative time printed is computed from --now, so that the output does not change between runs
+	data := &Data{
+		Certificate: gen.Certificate("test-crt", gen.SetCertificateNamespace("ns1"),
+			gen.SetCertificateNotAfter(metav1.Time{Time: now.Add(30 * 24 * time.Hour)})),
+		CrtEvents: &corev1.EventList{Items: []corev1.Event{{Type: corev1.EventTypeNormal, Reason: "Issuing",
+			Message: "Issued", FirstTimestamp: metav1.Time{Time: now.Add(-2 * 24 * time.Hour)}}}},
+		IssuerError: errors.New("error when getting Issuer\n"),
+		SecretError: errors.New("error when finding Secret\n"),
+		ReqError:    errors.New("error when finding CertificateRequest\n"),
+	}
+	output := o.buildStatus(data, o.clock()).String()
+	assert.Contains(t, output, "Not After: 2020-10-16T09:26:18Z (in 30d)\n")
+	assert.Regexp(t, `Normal\s+Issuing\s+2d\s+`, output)
+}
+
+func TestDynamicResourceClient(t *testing.T) {
+	crt := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "certmanager.example.com/v1",
+		"kind":       "Certificate",
+		"metadata": map[string]interface{}{"name": "test-crt", "namespace": "ns1",
+			"labels": map[string]interface{}{"app": "my-app"}},
+		"spec": map[string]interface{}{
+			"secretName": "test-tls",
+			"dnsNames":   []interface{}{"example.com"},
+			"issuerRef":  map[string]interface{}{"name": "ca-issuer", "group": "certmanager.example.com"},
+		},
+	}}
+	issuer := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "certmanager.example.com/v1",
+		"kind":       "Issuer",
+		"metadata":   map[string]interface{}{"name": "ca-issuer", "namespace": "ns1"},
+		"spec":       map[string]interface{}{"ca": map[string]interface{}{"secretName": "ca-key-pair"}},
+	}}
+	client := dynamicResourceClient{client: fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), crt, issuer),
+		group: "certmanager.example.com", version: "v1"}
+	ctx := context.TODO()
+
+	gotCrt, err := client.getCertificate(ctx, "ns1", "test-crt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "test-tls", gotCrt.Spec.SecretName)
+	assert.Equal(t, []string{"example.com"}, gotCrt.Spec.DNSNames)
+
+	gotIssuer, gotKind, err := getGenericIssuer(client, ctx, gotCrt, "certmanager.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "Issuer", gotKind)
+	assert.Equal(t, &cmapi.CAIssuer{SecretName: "ca-key-pair"}, gotIssuer.GetSpec().CA)
+
+	issuers, err := client.listIssuers(ctx, metav1.NamespaceAll, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, issuers.Items, 1) {
+		assert.Equal(t, "ca-issuer", issuers.Items[0].Name)
+	}
+
+	crts, err := client.listCertificates(ctx, metav1.NamespaceAll, metav1.ListOptions{LabelSelector: "app=my-app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, crts.Items, 1) {
+		assert.Equal(t, "test-crt", crts.Items[0].Name)
+	}
+	crts, err = client.listCertificates(ctx, "ns1", metav1.ListOptions{LabelSelector: "app=other-app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, crts.Items)
+
+	if _, err := client.getClusterIssuer(ctx, "ca-issuer"); err == nil {
+		t.Error("expected error when getting a ClusterIssuer that does not exist")
+	}
+}
+
+func TestCheckDNS01Challenges(t *testing.T) {
+	challenges := []*cmacme.Challenge{
+		{ObjectMeta: metav1.ObjectMeta{Name: "dns-pending"},
+			Spec:   cmacme.ChallengeSpec{Type: cmacme.ACMEChallengeTypeDNS01, DNSName: "example.com", Key: "expected-key"},
+			Status: cmacme.ChallengeStatus{State: cmacme.Pending}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "dns-valid"},
+			Spec:   cmacme.ChallengeSpec{Type: cmacme.ACMEChallengeTypeDNS01, DNSName: "valid.example.com", Key: "key"},
+			Status: cmacme.ChallengeStatus{State: cmacme.Valid}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "http-pending"},
+			Spec:   cmacme.ChallengeSpec{Type: cmacme.ACMEChallengeTypeHTTP01, DNSName: "http.example.com", Key: "key"},
+			Status: cmacme.ChallengeStatus{State: cmacme.Pending}},
+	}
+	lookup := func(fqdn, nameserver string) ([]string, error) {
+		if fqdn != "_acme-challenge.example.com." {
+			t.Errorf("unexpected lookup of %q", fqdn)
+		}
+		switch nameserver {
+		case "10.0.0.1:53":
+			return []string{"other", "expected-key"}, nil
+		case "10.0.0.2:53":
+			return nil, nil
+		default:
+			return nil, errors.New("i/o timeout")
+		}
+	}
+	nameservers := withDefaultDNSPort([]string{"10.0.0.1", "10.0.0.2:53", "10.0.0.3"})
+	lookups := checkDNS01Challenges(challenges, nameservers, lookup)
+	assert.Equal(t, map[string][]DNSLookup{"dns-pending": {
+		{Nameserver: "10.0.0.1:53", Records: []string{"other", "expected-key"}},
+		{Nameserver: "10.0.0.2:53"},
+		{Nameserver: "10.0.0.3:53", Error: errors.New("i/o timeout")},
+	}}, lookups)
+
+	status := (&CertificateStatus{}).withChallenges(challenges, nil).withDNSChecks(lookups)
+	expOutput := `Name: dns-pending, Type: DNS-01, DNS Name: example.com, Token: , Key: expected-key, State: pending, Reason: , Processing: false, Presented: false
+  DNS Check of _acme-challenge.example.com.:
+    Expected: "expected-key"
+    10.0.0.1:53: propagated, observed: "other", "expected-key"
+    10.0.0.2:53: not propagated, observed: <none>
+    10.0.0.3:53: error: i/o timeout`
+	if actualOutput := status.ChallengeStatusList.ChallengeStatuses[0].String(); actualOutput != expOutput {
+		t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", expOutput, actualOutput)
+	}
+	assert.Nil(t, status.ChallengeStatusList.ChallengeStatuses[1].DNSCheck)
+	assert.Nil(t, status.ChallengeStatusList.ChallengeStatuses[2].DNSCheck)
+}
+
+func TestLookupTXTTimeout(t *testing.T) {
+	// A nameserver that never answers
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	start := time.Now()
+	if _, err := newLookupTXT(100*time.Millisecond)("_acme-challenge.example.com.", conn.LocalAddr().String()); err == nil {
+		t.Error("expected error when the nameserver does not answer")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the lookup to time out after 100ms, took %s", elapsed)
+	}
+}
+
+func TestSerialNumberDecimalAndHex(t *testing.T) {
+	multiByteSerial, _ := new(big.Int).SetString("301696114246524167282555582613204853562", 10)
+	highBitSerial, _ := new(big.Int).SetString("80f1", 16)
+	tests := map[string]struct {
+		serial     *big.Int
+		expHex     string
+		expDecimal string
+	}{
+		"multi-byte serial": {
+			serial:     multiByteSerial,
+			expHex:     "E2:F8:8E:DC:94:2C:14:84:63:21:9D:A9:09:FD:63:3A",
+			expDecimal: "301696114246524167282555582613204853562",
+		},
+		"high bit set is still positive": {
+			serial:     highBitSerial,
+			expHex:     "80:F1",
+			expDecimal: "33009",
+		},
+		"negative serial keeps its sign": {
+			serial:     big.NewInt(-0x1234),
+			expHex:     "-12:34",
+			expDecimal: "-4660",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			output := (&SecretStatus{SerialNumber: test.serial, Version: 3}).String()
+			assert.Contains(t, output, "  Version: 3\n")
+			assert.Contains(t, output, "  Serial Number: "+test.expHex+"\n")
+			assert.Contains(t, output, "  Serial Number (Decimal): "+test.expDecimal+"\n")
+		})
+	}
+
+	certPEM, _ := generateCertPEM(t, &x509.Certificate{SerialNumber: multiByteSerial, Subject: pkix.Name{CommonName: "example.com"},
+		NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour)}, nil, nil)
+	secret := gen.Secret("test-tls", gen.SetSecretData(map[string][]byte{"tls.crt": certPEM}))
+	secretStatus := (&CertificateStatus{}).withSecret(secret, nil, nil, fakeclock.NewFakeClock(time.Now())).SecretStatus
+	if !assert.NoError(t, secretStatus.Error) {
+		return
+	}
+	assert.Equal(t, 3, secretStatus.Version)
+	assert.Equal(t, multiByteSerial, secretStatus.SerialNumber)
+}
+
+func TestDERSerialNumberToString(t *testing.T) {
+	highBitSerial, _ := new(big.Int).SetString("80f1", 16)
+	tests := map[string]struct {
+		serial *big.Int
+		expDER string
+		expHex string
+	}{
+		"high bit set keeps leading zero byte": {
+			serial: highBitSerial,
+			expDER: "00:80:F1",
+			expHex: "80:F1",
+		},
+		"high bit unset": {
+			serial: big.NewInt(0x7ff1),
+			expDER: "7F:F1",
+			expHex: "7F:F1",
+		},
+		"zero": {
+			serial: big.NewInt(0),
+			expDER: "00",
+			expHex: "00",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			der, err := derSerialNumberToString(test.serial)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expDER, der)
+
+			status := (&CertificateStatus{SecretStatus: &SecretStatus{SerialNumber: test.serial}}).withDERSerialNumber(true)
+			if !strings.Contains(status.SecretStatus.String(), "  Serial Number: "+test.expDER+"\n") {
+				t.Errorf("expected DER serial number %q in output:\n%s", test.expDER, status.SecretStatus.String())
+			}
+			status.withDERSerialNumber(false)
+			if !strings.Contains(status.SecretStatus.String(), "  Serial Number: "+test.expHex+"\n") {
+				t.Errorf("expected serial number %q in output:\n%s", test.expHex, status.SecretStatus.String())
+			}
+		})
+	}
+}
+
+func TestConditionsMap(t *testing.T) {
+	tests := map[string]struct {
+		conditions    []cmapi.CertificateCondition
+		expConditions map[string]string
+		expReady      bool
+		expOK         bool
+	}{
+		"Ready is True": {
+			conditions:    []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}},
+			expConditions: map[string]string{"Ready": "True"},
+			expReady:      true,
+			expOK:         true,
+		},
+		"Ready is False with a reason while issuing": {
+			conditions: []cmapi.CertificateCondition{
+				{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse, Reason: "DoesNotExist"},
+				{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue},
+			},
+			expConditions: map[string]string{"Ready": "False", "Issuing": "True"},
+			expReady:      false,
+			expOK:         true,
+		},
+		"no Conditions set": {
+			expConditions: nil,
+			expOK:         false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := &CertificateStatus{Conditions: test.conditions}
+			assert.Equal(t, test.expConditions, status.ConditionsMap())
+			ready, ok := status.IsReady()
+			assert.Equal(t, test.expReady, ready)
+			assert.Equal(t, test.expOK, ok)
+		})
+	}
+
+	issuerStatus := &IssuerStatus{Conditions: []cmapi.IssuerCondition{{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionUnknown}}}
+	assert.Equal(t, map[string]string{"Ready": "Unknown"}, issuerStatus.ConditionsMap())
+	ready, ok := issuerStatus.IsReady()
+	assert.False(t, ready)
+	assert.False(t, ok)
+}
+
+func TestToJSON(t *testing.T) {
+	notAfter := metav1.NewTime(time.Date(2020, 6, 11, 0, 0, 0, 0, time.UTC))
+	serial, _ := new(big.Int).SetString("301696114246524167282555582613204853562", 10)
+	status := &CertificateStatus{
+		Name:         "test-crt",
+		Namespace:    "ns1",
+		CreationTime: metav1.NewTime(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)),
+		Conditions: []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady,
+			Status: cmmeta.ConditionTrue, Reason: "Ready", Message: "Certificate is up to date and has not expired"}},
+		DNSNames: []string{"example.com"},
+		NotAfter: &notAfter,
+		Validity: &ValidityStatus{ExpiresIn: newDurationStatus(10 * 24 * time.Hour)},
+		IssuerStatus: &IssuerStatus{Name: "ca-issuer", Kind: "Issuer",
+			CAStatus: &IssuerCAStatus{Error: errors.New("error when finding CA Secret \"ca-key-pair\"\n")}},
+		SecretStatus: &SecretStatus{Name: "test-tls", IssuerCommonName: "ca", KeyUsage: x509.KeyUsageDigitalSignature,
+			PublicKeyAlgorithm: x509.ECDSA, SignatureAlgorithm: x509.ECDSAWithSHA256,
+			SubjectKeyId: []byte{0x01, 0xab}, AuthorityKeyId: []byte{0xff}, SerialNumber: serial,
+			NotBefore: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), NotAfter: notAfter.Time, ExpiresIn: 10 * 24 * time.Hour},
+		CRStatus: &CRStatus{Error: errors.New("No CertificateRequest found for this Certificate\n")},
+	}
+
+	expJSON := `{
+  "name": "test-crt",
+  "namespace": "ns1",
+  "creationTime": "2020-06-01T00:00:00Z",
+  "conditions": [{"type": "Ready", "status": "True", "reason": "Ready", "message": "Certificate is up to date and has not expired"}],
+  "conditionsMap": {"Ready": "True"},
+  "dnsNames": ["example.com"],
+  "notAfter": "2020-06-11T00:00:00Z",
+  "validity": {"expiresIn": {"seconds": 864000, "iso8601": "P10D"}},
+  "issuer": {
+    "name": "ca-issuer",
+    "kind": "Issuer",
+    "ca": {"error": "error when finding CA Secret \"ca-key-pair\""}
+  },
+  "secret": {
+    "name": "test-tls",
+    "issuerCommonName": "ca",
+    "keyUsage": "Digital Signature",
+    "publicKeyAlgorithm": "ECDSA",
+    "signatureAlgorithm": "ECDSA-SHA256",
+    "subjectKeyId": "01ab",
+    "notBefore": "2020-06-01T00:00:00Z",
+    "notAfter": "2020-06-11T00:00:00Z",
+    "expiresIn": {"seconds": 864000, "iso8601": "P10D"},
+    "notYetValid": false,
+    "expired": false,
+    "authorityKeyId": "ff",
+    "serialNumber": "301696114246524167282555582613204853562",
+    "isPrecertificate": false,
+    "ownedByCertificate": false
+  },
+  "certificateRequest": {"error": "No CertificateRequest found for this Certificate"}
+}`
+
+	var buf bytes.Buffer
+	if err := status.ToJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	assert.JSONEq(t, expJSON, buf.String())
+}
+
+func TestToYAML(t *testing.T) {
+	status := &CertificateStatus{
+		Name:         "test-crt",
+		Namespace:    "ns1",
+		CreationTime: metav1.NewTime(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)),
+		IssuerStatus: &IssuerStatus{Error: errors.New("error when getting Issuer: not found\n")},
+		SecretStatus: &SecretStatus{Name: "test-tls", KeyUsage: x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, PublicKeyAlgorithm: x509.RSA,
+			SignatureAlgorithm: x509.SHA256WithRSA, SerialNumber: big.NewInt(10),
+			NotBefore: time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC), NotAfter: time.Date(2020, 5, 31, 0, 0, 0, 0, time.UTC),
+			ExpiresIn: -24 * time.Hour, Expired: true},
+	}
+
+	// No CRStatus, OrderStatus or ChallengeStatusList, so they are omitted
+	expYAML := `creationTime: "2020-06-01T00:00:00Z"
+issuer:
+  error: 'error when getting Issuer: not found'
+name: test-crt
+namespace: ns1
+secret:
+  expired: true
+  expiresIn:
+    iso8601: -P1D
+    seconds: -86400
+  extKeyUsage: Server Authentication
+  isPrecertificate: false
+  keyUsage: Digital Signature, Key Encipherment
+  name: test-tls
+  notAfter: "2020-05-31T00:00:00Z"
+  notBefore: "2020-05-01T00:00:00Z"
+  notYetValid: false
+  ownedByCertificate: false
+  publicKeyAlgorithm: RSA
+  serialNumber: "10"
+  signatureAlgorithm: SHA256-RSA
+warnings:
+- code: CertificateExpired
+  message: the certificate in the Secret has expired
+  severity: error
+`
+
+	var buf bytes.Buffer
+	if err := status.ToYAML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if actualOutput := buf.String(); actualOutput != expYAML {
+		t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", expYAML, actualOutput)
+	}
+}
+
+func TestWithSecretSubjectAlternativeNames(t *testing.T) {
+	spiffeURI, err := url.Parse("spiffe://cluster.local/ns/ns1/sa/default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM, _ := generateCertPEM(t, &x509.Certificate{
+		SerialNumber:   big.NewInt(1),
+		Subject:        pkix.Name{CommonName: "example.com"},
+		NotBefore:      time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:       time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC),
+		DNSNames:       []string{"example.com", "www.example.com"},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("::1")},
+		URIs:           []*url.URL{spiffeURI},
+		EmailAddresses: []string{"admin@example.com"},
+	}, nil, nil)
+
+	status := (&CertificateStatus{}).withSecret(gen.Secret("test-tls",
+		gen.SetSecretData(map[string][]byte{"tls.crt": certPEM})), nil, nil, fakeclock.NewFakeClock(time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, []string{"example.com", "www.example.com"}, status.SecretStatus.DNSNames)
+	assert.Equal(t, []string{"10.0.0.1", "::1"}, status.SecretStatus.IPAddresses)
+	assert.Equal(t, []string{"spiffe://cluster.local/ns/ns1/sa/default"}, status.SecretStatus.URIs)
+	assert.Equal(t, []string{"admin@example.com"}, status.SecretStatus.EmailAddresses)
+
+	expSANs := `  Subject Alternative Names:
+    DNS Names: example.com, www.example.com
+    IP Addresses: 10.0.0.1, ::1
+    URIs: spiffe://cluster.local/ns/ns1/sa/default
+    Email Addresses: admin@example.com
+`
+	if actualOutput := status.SecretStatus.String(); !strings.Contains(actualOutput, expSANs) {
+		t.Errorf("Unexpected output; expected to contain: \n%s\nactual: \n%s", expSANs, actualOutput)
+	}
+}
+
+func TestWithSecretAnnotations(t *testing.T) {
+	certPEM, _ := generateCertPEM(t, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC),
+	}, nil, nil)
+
+	tests := map[string]struct {
+		annotations    map[string]string
+		expAnnotations map[string]string
+		expMismatch    bool
+		expOutput      string
+	}{
+		"no annotations": {
+			annotations:    nil,
+			expAnnotations: nil,
+		},
+		"unrelated annotations are ignored": {
+			annotations:    map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{}"},
+			expAnnotations: nil,
+		},
+		"annotations of the Certificate": {
+			annotations: map[string]string{"cert-manager.io/certificate-name": "test-crt", "cert-manager.io/issuer-name": "ca-issuer",
+				"kubectl.kubernetes.io/last-applied-configuration": "{}"},
+			expAnnotations: map[string]string{"cert-manager.io/certificate-name": "test-crt", "cert-manager.io/issuer-name": "ca-issuer"},
+			expOutput: `  Annotations:
+    cert-manager.io/certificate-name: test-crt
+    cert-manager.io/issuer-name: ca-issuer
+`,
+		},
+		"annotations of another Certificate": {
+			annotations:    map[string]string{"cert-manager.io/certificate-name": "other-crt"},
+			expAnnotations: map[string]string{"cert-manager.io/certificate-name": "other-crt"},
+			expMismatch:    true,
+			expOutput: `  Annotations:
+    cert-manager.io/certificate-name: other-crt
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := (&CertificateStatus{Name: "test-crt"}).withSecret(gen.Secret("test-tls",
+				gen.SetSecretAnnotations(test.annotations),
+				gen.SetSecretData(map[string][]byte{"tls.crt": certPEM})), nil, nil, fakeclock.NewFakeClock(time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)))
+			assert.Equal(t, test.expAnnotations, status.SecretStatus.Annotations)
+			assert.Equal(t, test.expMismatch, status.SecretStatus.CertificateNameMismatch)
+			if actualOutput := status.SecretStatus.String(); !strings.Contains(actualOutput, test.expOutput) {
+				t.Errorf("Unexpected output; expected to contain: \n%s\nactual: \n%s", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestWithSecretTypeAndOwner(t *testing.T) {
+	certPEM, _ := generateCertPEM(t, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC),
+	}, nil, nil)
+	crt := gen.Certificate("test-crt", gen.SetCertificateUID("crt-uid"))
+	crtGVK := cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateKind)
+	crtOwnerRef := *metav1.NewControllerRef(crt, crtGVK)
+	// Certificates with another UID than crt
+	otherCrt := gen.Certificate("other-crt", gen.SetCertificateUID("other-uid"))
+	deletedCrt := gen.Certificate("test-crt", gen.SetCertificateUID("deleted-uid"))
+	otherGroupCrt := gen.Certificate("test-crt", gen.SetCertificateUID("example-uid"))
+
+	tests := map[string]struct {
+		secretType     corev1.SecretType
+		ownerRefs      []metav1.OwnerReference
+		expOwned       bool
+		expWarningsLen int
+		expOutput      string
+	}{
+		"TLS Secret owned by the Certificate": {
+			secretType: corev1.SecretTypeTLS,
+			ownerRefs:  []metav1.OwnerReference{crtOwnerRef},
+			expOwned:   true,
+			expOutput: `Secret:
+  Name: test-tls
+  Type: kubernetes.io/tls
+  Owned by Certificate: true
+`,
+		},
+		"TLS Secret owned by the Certificate served under another API group": {
+			secretType: corev1.SecretTypeTLS,
+			ownerRefs:  []metav1.OwnerReference{*metav1.NewControllerRef(crt, schema.GroupVersionKind{Group: "certmanager.example.com", Version: "v1", Kind: cmapi.CertificateKind})},
+			expOwned:   true,
+			expOutput: `Secret:
+  Name: test-tls
+  Type: kubernetes.io/tls
+  Owned by Certificate: true
+`,
+		},
+		"TLS Secret owned by another Certificate": {
+			secretType: corev1.SecretTypeTLS,
+			ownerRefs:  []metav1.OwnerReference{*metav1.NewControllerRef(otherCrt, crtGVK)},
+			expOwned:   false,
+			expOutput: `Secret:
+  Name: test-tls
+  Type: kubernetes.io/tls
+  Owned by Certificate: false
+`,
+		},
+		"TLS Secret left behind by a deleted Certificate of the same name": {
+			secretType: corev1.SecretTypeTLS,
+			ownerRefs:  []metav1.OwnerReference{*metav1.NewControllerRef(deletedCrt, crtGVK)},
+			expOwned:   false,
+			expOutput: `Secret:
+  Name: test-tls
+  Type: kubernetes.io/tls
+  Owned by Certificate: false
+`,
+		},
+		"Opaque Secret owned by a resource of another group": {
+			secretType:     corev1.SecretTypeOpaque,
+			ownerRefs:      []metav1.OwnerReference{*metav1.NewControllerRef(otherGroupCrt, schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: cmapi.CertificateKind})},
+			expOwned:       false,
+			expWarningsLen: 1,
+			expOutput: `Secret:
+  Name: test-tls
+  Type: Opaque
+  Owned by Certificate: false
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			secret := gen.Secret("test-tls", gen.SetSecretData(map[string][]byte{"tls.crt": certPEM}))
+			secret.Type, secret.OwnerReferences = test.secretType, test.ownerRefs
+			status := (&CertificateStatus{Name: "test-crt"}).withSecret(secret, nil, nil,
+				fakeclock.NewFakeClock(time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC))).withSecretOwner(secret, crt)
+			assert.Equal(t, test.secretType, status.SecretStatus.Type)
+			assert.Equal(t, test.expOwned, status.SecretStatus.OwnedByCertificate)
+			assert.Len(t, status.warnings(), test.expWarningsLen)
+			if actualOutput := status.SecretStatus.String(); !strings.HasPrefix(actualOutput, test.expOutput) {
+				t.Errorf("Unexpected output; expected to start with: \n%s\nactual: \n%s", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestSecretStatusValidity(t *testing.T) {
+	notBefore := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC)
+	certPEM, _ := generateCertPEM(t, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}, nil, nil)
+	secret := gen.Secret("test-tls", gen.SetSecretData(map[string][]byte{"tls.crt": certPEM}))
+
+	tests := map[string]struct {
+		now            time.Time
+		expNotYetValid bool
+		expExpired     bool
+		expExpiresIn   time.Duration
+		expOutput      string
+	}{
+		"valid certificate": {
+			now:          time.Date(2020, 8, 2, 0, 0, 0, 0, time.UTC),
+			expExpiresIn: 30 * 24 * time.Hour,
+			expOutput:    "  Validity: Expires in 30d (2020-09-01T00:00:00Z)\n",
+		},
+		"expired certificate": {
+			now:          time.Date(2020, 9, 4, 0, 0, 0, 0, time.UTC),
+			expExpired:   true,
+			expExpiresIn: -3 * 24 * time.Hour,
+			expOutput:    "  Validity: EXPIRED 3d ago (2020-09-01T00:00:00Z)\n",
+		},
+		"not yet valid certificate": {
+			now:            time.Date(2020, 5, 30, 0, 0, 0, 0, time.UTC),
+			expNotYetValid: true,
+			expExpiresIn:   94 * 24 * time.Hour,
+			expOutput:      "  Validity: NOT YET VALID, valid from 2020-06-01T00:00:00Z\n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := (&CertificateStatus{}).withSecret(secret, nil, nil, fakeclock.NewFakeClock(test.now))
+			assert.Equal(t, test.expNotYetValid, status.SecretStatus.NotYetValid)
+			assert.Equal(t, test.expExpired, status.SecretStatus.Expired)
+			assert.Equal(t, test.expExpiresIn, status.SecretStatus.ExpiresIn)
+			if actualOutput := status.SecretStatus.String(); !strings.Contains(actualOutput, test.expOutput) {
+				t.Errorf("Unexpected output; expected to contain: \n%s\nactual: \n%s", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	readyCond := []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}}
+	notFoundErr := fmt.Errorf("error when finding Secret \"test-tls\": %w\n",
+		apierrors.NewNotFound(corev1.Resource("secrets"), "test-tls"))
+
+	tests := map[string]struct {
+		status  *CertificateStatus
+		expCode int
+	}{
+		"Ready Certificate without a CertificateRequest for the next revision": {
+			status: &CertificateStatus{Conditions: readyCond, IssuerStatus: &IssuerStatus{Name: "ca-issuer"},
+				SecretStatus: &SecretStatus{Name: "test-tls"}, CRStatus: &CRStatus{Error: errNoCertificateRequest}},
+			expCode: 0,
+		},
+		"Certificate without Ready condition": {
+			status:  &CertificateStatus{},
+			expCode: exitCodeNotReady,
+		},
+		"Certificate not Ready whose Secret does not exist yet": {
+			status: &CertificateStatus{Conditions: []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse}},
+				SecretStatus: &SecretStatus{Error: notFoundErr}},
+			expCode: exitCodeNotReady,
+		},
+		"Issuer could not be found": {
+			status: &CertificateStatus{Conditions: readyCond,
+				IssuerStatus: &IssuerStatus{Error: errors.New("error when getting Issuer: not found\n")}},
+			expCode: exitCodeLookupError,
+		},
+		"CertificateRequests could not be listed": {
+			status:  &CertificateStatus{CRStatus: &CRStatus{Error: errors.New("error when finding CertificateRequest: forbidden\n")}},
+			expCode: exitCodeLookupError,
+		},
+		"Ready Certificate below signature policy": {
+			status: &CertificateStatus{Conditions: readyCond,
+				SecretStatus: &SecretStatus{SignaturePolicy: &SignaturePolicyStatus{Strength: "weak", MinStrength: "sha256"}}},
+			expCode: exitCodeSignaturePolicy,
+		},
+		"Ready Certificate whose certificate has expired": {
+			status:  &CertificateStatus{Conditions: readyCond, SecretStatus: &SecretStatus{Expired: true}},
+			expCode: exitCodeWarningError,
+		},
+		"Ready Certificate with only warnings below the error severity": {
+			status:  &CertificateStatus{Conditions: readyCond, DurationShortened: true, SecretContention: []string{"other-crt"}},
+			expCode: 0,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			code, _ := test.status.exitCode()
+			assert.Equal(t, test.expCode, code)
+		})
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	keyMismatch := false
+	tests := map[string]struct {
+		status      *CertificateStatus
+		expWarnings []Warning
+		expHighest  WarningSeverity
+		expOutput   string
+	}{
+		"healthy Certificate has no Warnings section": {
+			status: &CertificateStatus{},
+		},
+		"each check is listed with its severity and code": {
+			status: &CertificateStatus{DurationShortened: true, SecretContention: []string{"other-crt"},
+				SecretStatus: &SecretStatus{KeyMatchesCert: &keyMismatch}},
+			expWarnings: []Warning{
+				{Severity: WarningSeverityInfo, Code: "DurationShortened", Message: "the issuer shortened the duration of the certificate"},
+				{Severity: WarningSeverityWarning, Code: "SecretContention", Message: "Secret contention with: other-crt"},
+				{Severity: WarningSeverityError, Code: "KeyMismatch", Message: "the private key in the Secret does not match the certificate"},
+			},
+			expHighest: WarningSeverityError,
+			expOutput: `Warnings:
+  info: the issuer shortened the duration of the certificate (DurationShortened)
+  warning: Secret contention with: other-crt (SecretContention)
+  error: the private key in the Secret does not match the certificate (KeyMismatch)
+`,
+		},
+		"highest severity of warnings only": {
+			status: &CertificateStatus{Validity: &ValidityStatus{RenewalOverdue: true}},
+			expWarnings: []Warning{
+				{Severity: WarningSeverityWarning, Code: "RenewalOverdue", Message: "the Renewal Time has passed"},
+			},
+			expHighest: WarningSeverityWarning,
+			expOutput: `Warnings:
+  warning: the Renewal Time has passed (RenewalOverdue)
+`,
+		},
+		"Overdue renewal state without a Renewal Time": {
+			status: &CertificateStatus{RenewalState: renewalStateOverdue},
+			expWarnings: []Warning{
+				{Severity: WarningSeverityWarning, Code: "RenewalStateOverdue",
+					Message: "the Certificate should have been renewed but is not being reissued or its certificate has expired"},
+			},
+			expHighest: WarningSeverityWarning,
+			expOutput: `Warnings:
+  warning: the Certificate should have been renewed but is not being reissued or its certificate has expired (RenewalStateOverdue)
+`,
+		},
+		"Overdue renewal state past the Renewal Time": {
+			status: &CertificateStatus{RenewalState: renewalStateOverdue, Validity: &ValidityStatus{RenewalOverdue: true}},
+			expWarnings: []Warning{
+				{Severity: WarningSeverityWarning, Code: "RenewalStateOverdue",
+					Message: "the Certificate should have been renewed but is not being reissued or its certificate has expired"},
+			},
+			expHighest: WarningSeverityWarning,
+			expOutput: `Warnings:
+  warning: the Certificate should have been renewed but is not being reissued or its certificate has expired (RenewalStateOverdue)
+`,
+		},
+		"Overdue renewal state of an expired certificate": {
+			status: &CertificateStatus{RenewalState: renewalStateOverdue, Validity: &ValidityStatus{RenewalOverdue: true},
+				SecretStatus: &SecretStatus{Expired: true}},
+			expWarnings: []Warning{
+				{Severity: WarningSeverityError, Code: "CertificateExpired", Message: "the certificate in the Secret has expired"},
+			},
+			expHighest: WarningSeverityError,
+			expOutput: `Warnings:
+  error: the certificate in the Secret has expired (CertificateExpired)
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			warnings := test.status.Warnings()
+			assert.Equal(t, test.expWarnings, warnings)
+			assert.Equal(t, test.expHighest, highestSeverity(warnings))
+
+			var buf bytes.Buffer
+			test.status.writeWarnings(&statusWriter{w: &buf})
+			assert.Equal(t, test.expOutput, buf.String())
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	crtWithVersion := func(resourceVersion string, ready cmmeta.ConditionStatus) *Data {
+		return &Data{Certificate: &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-crt", Namespace: "testns", ResourceVersion: resourceVersion},
+			Status:     cmapi.CertificateStatus{Conditions: []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady, Status: ready}}},
+		}}
+	}
+
+	tests := map[string]struct {
+		polls      []*Data
+		stop       bool
+		expReady   bool
+		expRefresh int
+	}{
+		"prints again only when a resource changed and returns once Ready": {
+			polls:      []*Data{crtWithVersion("1", cmmeta.ConditionFalse), crtWithVersion("2", cmmeta.ConditionTrue)},
+			expReady:   true,
+			expRefresh: 2,
+		},
+		"returns when stopped before the Certificate is Ready": {
+			stop:       true,
+			expRefresh: 1,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			polls := test.polls
+			getData := func() (*Data, error) {
+				if len(polls) == 0 {
+					return nil, errors.New("unexpected poll")
+				}
+				data := polls[0]
+				polls = polls[1:]
+				return data, nil
+			}
+			fakeClock := fakeclock.NewFakeClock(time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC))
+			stop := make(chan os.Signal, 1)
+			if test.stop {
+				stop <- os.Interrupt
+			}
+
+			type result struct {
+				status *CertificateStatus
+				err    error
+			}
+			done := make(chan result)
+			out := &bytes.Buffer{}
+			o := &Options{Interval: time.Second, Format: "summary"}
+			go func() {
+				status, err := o.watch(out, crtWithVersion("1", cmmeta.ConditionFalse), getData, fakeClock, stop)
+				done <- result{status, err}
+			}()
+
+			var res result
+		wait:
+			for {
+				select {
+				case res = <-done:
+					break wait
+				default:
+					if fakeClock.HasWaiters() {
+						fakeClock.Step(time.Second)
+					}
+					time.Sleep(time.Millisecond)
+				}
+			}
+
+			assert.NoError(t, res.err)
+			ready, _ := res.status.IsReady()
+			assert.Equal(t, test.expReady, ready)
+			assert.Equal(t, test.expRefresh, strings.Count(out.String(), "=== 2020-07-01T00:00:0"))
+			assert.Empty(t, polls)
+		})
+	}
+}
+
+func TestWriteWide(t *testing.T) {
+	revision := 3
+	readyCond := cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}
+	notReadyCond := cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse}
+	statuses := []*CertificateStatus{
+		{Name: "web", Namespace: "prod", Revision: &revision, Conditions: []cmapi.CertificateCondition{readyCond},
+			SecretName:   "web-tls",
+			Validity:     &ValidityStatus{RenewalIn: newDurationStatus(-2 * time.Hour)},
+			IssuerStatus: &IssuerStatus{Name: "letsencrypt-prod", Kind: "ClusterIssuer"},
+			SecretStatus: &SecretStatus{Name: "web-tls", ExpiresIn: 29 * 24 * time.Hour}},
+		{Name: "api", Namespace: "dev", Conditions: []cmapi.CertificateCondition{notReadyCond},
+			SecretName:   "api-tls",
+			IssuerStatus: &IssuerStatus{Error: errors.New("error when getting Issuer: forbidden\n")},
+			SecretStatus: &SecretStatus{Error: apierrors.NewNotFound(corev1.Resource("secrets"), "api-tls")}},
+		{Name: "new", Namespace: "dev", SecretName: "new-tls",
+			SecretStatus: &SecretStatus{Error: errors.New("error when finding Secret \"new-tls\": timeout\n")}},
+	}
+
+	var buf bytes.Buffer
+	if err := writeWide(&buf, statuses, true); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `NAMESPACE  NAME  READY    SECRET               ISSUER            EXPIRES    RENEWAL   REVISION
+prod       web   True     web-tls              letsencrypt-prod  in 29d     120m ago  3
+dev        api   False    api-tls (not found)  <error>           <unknown>  <none>    <none>
+dev        new   Unknown  new-tls (error)      <none>            <unknown>  <none>    <none>
+`, buf.String())
+
+	buf.Reset()
+	if err := writeWide(&buf, statuses[:1], false); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `NAME  READY  SECRET   ISSUER            EXPIRES  RENEWAL   REVISION
+web   True   web-tls  letsencrypt-prod  in 29d   120m ago  3
+`, buf.String())
+}
+
+func TestParseOutputTemplate(t *testing.T) {
+	templateFile, err := ioutil.TempFile("", "status-template")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(templateFile.Name())
+	if _, err := templateFile.WriteString(`{{.SecretStatus.Name}}`); err != nil {
+		t.Fatal(err)
+	}
+	templateFile.Close()
+
+	status := &CertificateStatus{Name: "test-crt", SecretStatus: &SecretStatus{
+		Name:         "test-tls",
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		SubjectKeyId: []byte{0xab, 0xcd},
+		SerialNumber: big.NewInt(42),
+	}}
+
+	tests := map[string]struct {
+		output    string
+		expErr    bool
+		expOutput string
+	}{
+		"template of fields": {
+			output:    "go-template={{.Name}} {{.SecretStatus.SerialNumber}}",
+			expOutput: "test-crt 42",
+		},
+		"template using the helper functions": {
+			output:    "go-template={{hex .SecretStatus.SubjectKeyId}}; {{keyUsage .SecretStatus.KeyUsage}}; {{extKeyUsage .SecretStatus.ExtKeyUsage}}",
+			expOutput: "abcd; Digital Signature, Key Encipherment; Server Authentication",
+		},
+		"template read from file": {
+			output:    "go-template-file=" + templateFile.Name(),
+			expOutput: "test-tls",
+		},
+		"empty template": {
+			output: "go-template=",
+			expErr: true,
+		},
+		"template that does not parse": {
+			output: "go-template={{.Name",
+			expErr: true,
+		},
+		"template file that does not exist": {
+			output: "go-template-file=" + templateFile.Name() + "-missing",
+			expErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := parseOutputTemplate(test.output)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			out := &bytes.Buffer{}
+			assert.NoError(t, tmpl.Execute(out, status))
+			assert.Equal(t, test.expOutput, out.String())
+		})
+	}
+}
+
+func TestSecretStatusFingerprintAlgorithm(t *testing.T) {
+	secretStatus := &SecretStatus{SerialNumber: big.NewInt(1), FingerprintSHA256: "AB:CD", FingerprintSHA1: "EF:01"}
+
+	tests := map[string]struct {
+		algorithm string
+		expLine   string
+	}{
+		"default is SHA-256": {algorithm: "", expLine: "  Fingerprint (SHA-256): AB:CD\n"},
+		"SHA-256":            {algorithm: fingerprintAlgorithmSHA256, expLine: "  Fingerprint (SHA-256): AB:CD\n"},
+		"SHA-1":              {algorithm: fingerprintAlgorithmSHA1, expLine: "  Fingerprint (SHA-1): EF:01\n"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := (&CertificateStatus{SecretStatus: secretStatus}).withFingerprintAlgorithm(test.algorithm)
+			assert.Contains(t, status.SecretStatus.String(), test.expLine)
+		})
+	}
+}
+
+func TestWithSpecDrift(t *testing.T) {
+	matches, mismatches := true, false
+	tests := map[string]struct {
+		specDNSNames   []string
+		specIsCA       bool
+		secretStatus   *SecretStatus
+		expMatches     *bool
+		expMissing     []string
+		expExtra       []string
+		expIssuedNotCA bool
+		expWarning     string
+	}{
+		"Secret could not be read": {
+			specDNSNames: []string{"example.com"},
+			secretStatus: &SecretStatus{Error: errors.New("not found")},
+			expMatches:   nil,
+		},
+		"same DNS Names in a different order and case": {
+			specDNSNames: []string{"www.example.com", "Example.com"},
+			secretStatus: &SecretStatus{DNSNames: []string{"example.com", "WWW.example.com"}},
+			expMatches:   &matches,
+		},
+		"DNS Names edited since the certificate was issued": {
+			specDNSNames: []string{"example.com", "new.example.com"},
+			secretStatus: &SecretStatus{DNSNames: []string{"example.com", "old.example.com"}},
+			expMatches:   &mismatches,
+			expMissing:   []string{"new.example.com"},
+			expExtra:     []string{"old.example.com"},
+			expWarning:   "the DNS Names differ from those of the certificate in the Secret",
+		},
+		"CA certificate requested and issued": {
+			specIsCA:     true,
+			secretStatus: &SecretStatus{BasicConstraintsValid: true, IsCA: true},
+			expMatches:   &matches,
+		},
+		"CA certificate requested but not issued": {
+			specIsCA:       true,
+			secretStatus:   &SecretStatus{BasicConstraintsValid: true},
+			expMatches:     &matches,
+			expIssuedNotCA: true,
+			expWarning:     "spec.isCA is set but the certificate in the Secret is not a CA certificate",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := (&CertificateStatus{DNSNames: test.specDNSNames, IsCA: test.specIsCA, SecretStatus: test.secretStatus}).withSpecDrift()
+			assert.Equal(t, test.expMatches, status.SpecMatchesIssued)
+			assert.Equal(t, test.expMissing, status.MissingDNSNames)
+			assert.Equal(t, test.expExtra, status.ExtraDNSNames)
+			assert.Equal(t, test.expIssuedNotCA, status.IssuedNotCA)
+			if test.expWarning != "" {
+				assert.Equal(t, []string{test.expWarning}, status.warnings())
+			} else {
+				assert.Empty(t, status.warnings())
+			}
+		})
+	}
+}
+
+func TestBasicConstraints(t *testing.T) {
+	tests := map[string]struct {
+		template *x509.Certificate
+		expOut   string
+	}{
+		"no Basic Constraints": {
+			template: &x509.Certificate{},
+			expOut:   "not set",
+		},
+		"not a CA certificate": {
+			template: &x509.Certificate{BasicConstraintsValid: true},
+			expOut:   "CA: false",
+		},
+		"CA certificate without a path length constraint": {
+			template: &x509.Certificate{BasicConstraintsValid: true, IsCA: true, MaxPathLen: -1},
+			expOut:   "CA: true, Max Path Length: unlimited",
+		},
+		"CA certificate with a path length of zero": {
+			template: &x509.Certificate{BasicConstraintsValid: true, IsCA: true, MaxPathLenZero: true},
+			expOut:   "CA: true, Max Path Length: 0",
+		},
+		"CA certificate with a path length of two": {
+			template: &x509.Certificate{BasicConstraintsValid: true, IsCA: true, MaxPathLen: 2},
+			expOut:   "CA: true, Max Path Length: 2",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.template.SerialNumber = big.NewInt(1)
+			test.template.NotBefore = time.Now()
+			test.template.NotAfter = time.Now().Add(time.Hour)
+			certPEM, _ := generateCertPEM(t, test.template, nil, nil)
+			status := (&CertificateStatus{}).withSecret(&corev1.Secret{Data: map[string][]byte{"tls.crt": certPEM}}, nil, nil,
+				fakeclock.NewFakeClock(time.Now()))
+			if !assert.NoError(t, status.SecretStatus.Error) {
+				return
+			}
+			assert.Equal(t, test.expOut, status.SecretStatus.basicConstraints())
+		})
+	}
+}
+
+func TestWriteAuthorityInformation(t *testing.T) {
+	tests := map[string]struct {
+		template  *x509.Certificate
+		expOutput string
+	}{
+		"no Authority Information": {
+			template:  &x509.Certificate{},
+			expOutput: "",
+		},
+		"OCSP server only": {
+			template: &x509.Certificate{OCSPServer: []string{"http://ocsp.example.com"}},
+			expOutput: `  Authority Information:
+    OCSP Servers: http://ocsp.example.com
+`,
+		},
+		"all set": {
+			template: &x509.Certificate{OCSPServer: []string{"http://ocsp.example.com"},
+				IssuingCertificateURL: []string{"http://ca.example.com/ca.crt"},
+				CRLDistributionPoints: []string{"http://crl.example.com/1.crl", "http://crl.example.com/2.crl"}},
+			expOutput: `  Authority Information:
+    OCSP Servers: http://ocsp.example.com
+    Issuing Certificate URLs: http://ca.example.com/ca.crt
+    CRL Distribution Points: http://crl.example.com/1.crl, http://crl.example.com/2.crl
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.template.SerialNumber = big.NewInt(1)
+			test.template.NotBefore = time.Now()
+			test.template.NotAfter = time.Now().Add(time.Hour)
+			certPEM, _ := generateCertPEM(t, test.template, nil, nil)
+			status := (&CertificateStatus{}).withSecret(&corev1.Secret{Data: map[string][]byte{"tls.crt": certPEM}}, nil, nil,
+				fakeclock.NewFakeClock(time.Now()))
+			if !assert.NoError(t, status.SecretStatus.Error) {
+				return
+			}
+			var buf bytes.Buffer
+			status.SecretStatus.writeAuthorityInformation(&statusWriter{w: &buf})
+			assert.Equal(t, test.expOutput, buf.String())
+		})
+	}
+}
+
+func TestPublicKeySize(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := pki.GenerateECPrivateKey(pki.ECCurve384)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		publicKey crypto.PublicKey
+		expBits   int
+		expCurve  string
+	}{
+		"RSA":     {publicKey: rsaKey.Public(), expBits: 1024},
+		"ECDSA":   {publicKey: ecKey.Public(), expBits: 384, expCurve: "P-384"},
+		"Ed25519": {publicKey: edPublicKey},
+		"unknown": {publicKey: "not a key"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			bits, curve := publicKeySize(test.publicKey)
+			assert.Equal(t, test.expBits, bits)
+			assert.Equal(t, test.expCurve, curve)
+		})
+	}
+
+	weakRSA := &SecretStatus{SerialNumber: big.NewInt(1), PublicKeyAlgorithm: x509.RSA, KeyBits: 1024}
+	assert.Contains(t, weakRSA.String(), "  Public Key Algorithm: RSA (1024 bit)\n")
+	assert.Contains(t, (&CertificateStatus{SecretStatus: weakRSA}).warnings(), "the RSA key of the certificate is weak")
+}
+
+func TestWithSecretEd25519(t *testing.T) {
+	_, sk, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "example.com"},
+		DNSNames: []string{"example.com"}, KeyUsage: x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		NotBefore:   time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, sk.Public(), sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := gen.Secret("test-tls", gen.SetSecretNamespace("ns1"), gen.SetSecretData(map[string][]byte{
+		"tls.crt": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		"tls.key": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+	}))
+
+	status := (&CertificateStatus{}).withSecret(secret, nil, nil, fakeclock.NewFakeClock(time.Now()))
+	secretStatus := status.SecretStatus
+	if !assert.NoError(t, secretStatus.Error) {
+		return
+	}
+	assert.Equal(t, "Ed25519", secretStatus.PublicKeyAlgorithm.String())
+	assert.Equal(t, x509.PureEd25519, secretStatus.SignatureAlgorithm)
+	assert.Zero(t, secretStatus.KeyBits)
+	assert.Empty(t, secretStatus.KeyCurve)
+	if assert.NotNil(t, secretStatus.KeyMatchesCert) {
+		assert.True(t, *secretStatus.KeyMatchesCert)
+	}
+	assert.Empty(t, status.warnings())
+
+	output := secretStatus.String()
+	assert.Contains(t, output, "  Public Key Algorithm: Ed25519\n")
+	assert.Contains(t, output, "  Signature Algorithm: Ed25519\n")
+	assert.Contains(t, output, "  Key Usage: Digital Signature\n")
+	assert.Contains(t, output, "  Extended Key Usages: Server Authentication\n")
+	assert.NotContains(t, output, "WARNING")
+}
+
+func TestWithSecretUnknownExtKeyUsage(t *testing.T) {
+	documentSigning := asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 10, 3, 12}
+	smartcardLogon := asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 20, 2, 2}
+	certPEM, _ := generateCertPEM(t, &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "example.com"},
+		NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour),
+		ExtKeyUsage:        []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		UnknownExtKeyUsage: []asn1.ObjectIdentifier{documentSigning, smartcardLogon}}, nil, nil)
+	secret := gen.Secret("test-tls", gen.SetSecretData(map[string][]byte{"tls.crt": certPEM}))
+
+	secretStatus := (&CertificateStatus{}).withSecret(secret, nil, nil, fakeclock.NewFakeClock(time.Now())).SecretStatus
+	if !assert.NoError(t, secretStatus.Error) {
+		return
+	}
+	assert.Equal(t, []asn1.ObjectIdentifier{documentSigning, smartcardLogon}, secretStatus.UnknownExtKeyUsage)
+	assert.Contains(t, secretStatus.String(), `  Extended Key Usages: Client Authentication
+    OID: 1.3.6.1.4.1.311.10.3.12
+    OID: 1.3.6.1.4.1.311.20.2.2
+  Basic Constraints: `)
+
+	data, err := json.Marshal(secretStatus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, string(data), `"unknownExtKeyUsage":["1.3.6.1.4.1.311.10.3.12","1.3.6.1.4.1.311.20.2.2"]`)
+}
+
+func TestNameConstraints(t *testing.T) {
+	_, permittedRange, _ := net.ParseCIDR("10.0.0.0/8")
+	tests := map[string]struct {
+		template       *x509.Certificate
+		expConstraints *NameConstraintsStatus
+		expOutput      string
+	}{
+		"CA scoped by DNS domains and IP ranges": {
+			template: &x509.Certificate{IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign,
+				PermittedDNSDomainsCritical: true, PermittedDNSDomains: []string{"example.com", ".internal.example.com"},
+				ExcludedDNSDomains: []string{"secret.example.com"}, PermittedIPRanges: []*net.IPNet{permittedRange}},
+			expConstraints: &NameConstraintsStatus{Critical: true,
+				PermittedDNSDomains: []string{"example.com", ".internal.example.com"},
+				ExcludedDNSDomains:  []string{"secret.example.com"}, PermittedIPRanges: []string{"10.0.0.0/8"}},
+			expOutput: `  Name Constraints:
+    Critical: true
+    Permitted DNS Domains: example.com, .internal.example.com
+    Excluded DNS Domains: secret.example.com
+    Permitted IP Ranges: 10.0.0.0/8
+`,
+		},
+		"CA without Name Constraints": {
+			template: &x509.Certificate{IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign},
+		},
+		"Name Constraints are only reported for CA certificates": {
+			template: &x509.Certificate{BasicConstraintsValid: true, PermittedDNSDomains: []string{"example.com"}},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.template.SerialNumber, test.template.Subject = big.NewInt(1), pkix.Name{CommonName: "ca"}
+			test.template.NotBefore, test.template.NotAfter = time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+			certPEM, _ := generateCertPEM(t, test.template, nil, nil)
+			secret := gen.Secret("ca-tls", gen.SetSecretData(map[string][]byte{"tls.crt": certPEM}))
+
+			secretStatus := (&CertificateStatus{}).withSecret(secret, nil, nil, fakeclock.NewFakeClock(time.Now())).SecretStatus
+			if !assert.NoError(t, secretStatus.Error) {
+				return
+			}
+			assert.Equal(t, test.expConstraints, secretStatus.NameConstraints)
+
+			var buf bytes.Buffer
+			secretStatus.writeNameConstraints(&statusWriter{w: &buf})
+			assert.Equal(t, test.expOutput, buf.String())
+		})
+	}
+}
+
+func TestRawX509(t *testing.T) {
+	customOID := asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 55555, 1}
+	notBefore := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	certPEM, _ := generateCertPEM(t, &x509.Certificate{SerialNumber: big.NewInt(16), Subject: pkix.Name{CommonName: "example.com"},
+		NotBefore: notBefore, NotAfter: notBefore.Add(90 * 24 * time.Hour),
+		DNSNames: []string{"example.com"}, IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+		KeyUsage: x509.KeyUsageDigitalSignature, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		ExtraExtensions:       []pkix.Extension{{Id: customOID, Critical: true, Value: []byte{0x04, 0x02, 0xab, 0xcd}}}}, nil, nil)
+	secret := gen.Secret("test-tls", gen.SetSecretData(map[string][]byte{"tls.crt": certPEM}))
+
+	status := (&CertificateStatus{}).withSecret(secret, nil, nil, fakeclock.NewFakeClock(notBefore))
+	if !assert.NoError(t, status.SecretStatus.Error) {
+		return
+	}
+	assert.NotContains(t, status.SecretStatus.String(), "Raw Certificate:")
+
+	output := status.withRawX509(true).SecretStatus.String()
+	for _, expLine := range []string{
+		"  Raw Certificate:\n",
+		"    Version: 3 (0x2)\n",
+		"    Serial Number: 10\n",
+		"    Signature Algorithm: ECDSA-SHA256\n",
+		"    Issuer: CN=example.com\n",
+		"      Not Before: 2020-06-01T00:00:00Z\n      Not After: 2020-08-30T00:00:00Z\n",
+		"      Public Key Algorithm: ECDSA\n      Public Key: (256 bit)\n      pub:\n",
+		"      Curve: P-256\n",
+		"      X509v3 Key Usage, critical:\n        Digital Signature\n",
+		"      X509v3 Extended Key Usage:\n        Server Authentication\n",
+		"      X509v3 Basic Constraints, critical:\n        CA:FALSE\n",
+		"      X509v3 Subject Alternative Name:\n        DNS:example.com, IP Address:10.0.0.1\n",
+		// Extensions that crypto/x509 does not decode are dumped as hex
+		"      1.3.6.1.4.1.55555.1, critical:\n        04:02:AB:CD\n",
+		"    Signature Value:\n",
+	} {
+		assert.Contains(t, output, expLine)
+	}
+}
+
+func TestRawHexLines(t *testing.T) {
+	b := make([]byte, rawHexBytesPerLine+1)
+	b[rawHexBytesPerLine] = 0xff
+	assert.Equal(t, []string{"00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:", "FF"}, rawHexLines(b))
+	assert.Equal(t, []string{"AB"}, rawHexLines([]byte{0xab}))
+}
+
+func TestWithRequiredPolicies(t *testing.T) {
+	domainValidated := asn1.ObjectIdentifier{2, 23, 140, 1, 2, 1}
+	organizationValidated := asn1.ObjectIdentifier{2, 23, 140, 1, 2, 2}
+	tests := map[string]struct {
+		policies       []asn1.ObjectIdentifier
+		required       []string
+		expMissing     []string
+		expOutputLines []string
+	}{
+		"no policies and none required skips the block": {},
+		"policies are printed in dotted notation": {
+			policies:       []asn1.ObjectIdentifier{domainValidated, organizationValidated},
+			expOutputLines: []string{"  Certificate Policies: 2.23.140.1.2.1, 2.23.140.1.2.2"},
+		},
+		"required policy present": {
+			policies:       []asn1.ObjectIdentifier{domainValidated},
+			required:       []string{"2.23.140.1.2.1"},
+			expOutputLines: []string{"  Certificate Policies: 2.23.140.1.2.1"},
+		},
+		"required policies missing": {
+			policies:   []asn1.ObjectIdentifier{domainValidated},
+			required:   []string{"2.23.140.1.2.1", "2.23.140.1.2.2", "1.3.6.1.4.1.44947.1.1.1"},
+			expMissing: []string{"2.23.140.1.2.2", "1.3.6.1.4.1.44947.1.1.1"},
+			expOutputLines: []string{"  Certificate Policies: 2.23.140.1.2.1",
+				"  Missing Certificate Policies: 2.23.140.1.2.2, 1.3.6.1.4.1.44947.1.1.1"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			certPEM, _ := generateCertPEM(t, &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "example.com"},
+				NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour), PolicyIdentifiers: test.policies}, nil, nil)
+			secret := gen.Secret("test-tls", gen.SetSecretData(map[string][]byte{"tls.crt": certPEM}))
+
+			status := (&CertificateStatus{}).withSecret(secret, nil, nil, fakeclock.NewFakeClock(time.Now())).withRequiredPolicies(test.required)
+			if !assert.NoError(t, status.SecretStatus.Error) {
+				return
+			}
+			assert.Equal(t, test.expMissing, status.SecretStatus.MissingPolicies)
+			output := status.SecretStatus.String()
+			for _, line := range test.expOutputLines {
+				assert.Contains(t, output, line+"\n")
+			}
+			if len(test.policies) == 0 {
+				assert.NotContains(t, output, "Certificate Policies")
+			}
+			if len(test.expMissing) == 0 {
+				assert.NotContains(t, output, "Missing Certificate Policies")
+			}
+		})
+	}
+
+	oid, err := parseOID("2.23.140.1.02.1")
+	assert.NoError(t, err)
+	assert.Equal(t, "2.23.140.1.2.1", oid.String())
+	for _, invalid := range []string{"", "2", "2.x.1", "2..1", "2.-1"} {
+		_, err := parseOID(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestWithSecretErrors(t *testing.T) {
+	notFoundErr := fmt.Errorf("error when finding Secret \"test-tls\": %w\n",
+		apierrors.NewNotFound(corev1.Resource("secrets"), "test-tls"))
+	forbiddenErr := fmt.Errorf("error when finding Secret \"test-tls\": %w\n",
+		apierrors.NewForbidden(corev1.Resource("secrets"), "test-tls", errors.New("denied")))
+
+	tests := map[string]struct {
+		issuing       bool
+		secret        *corev1.Secret
+		err           error
+		expNotCreated bool
+		expOutput     string
+	}{
+		"Secret not created yet while issuing": {
+			issuing:       true,
+			err:           notFoundErr,
+			expNotCreated: true,
+			expOutput:     "Secret \"test-tls\" not created yet (issuance in progress)\n",
+		},
+		"Secret not created yet while not issuing": {
+			err:           notFoundErr,
+			expNotCreated: true,
+			expOutput:     "Secret \"test-tls\" not created yet\n",
+		},
+		"other API errors are printed as they are": {
+			err:       forbiddenErr,
+			expOutput: forbiddenErr.Error(),
+		},
+		"malformed tls.crt of an existing Secret": {
+			secret:    gen.Secret("test-tls", gen.SetSecretData(map[string][]byte{"tls.crt": []byte("not a certificate")})),
+			expOutput: "error when parsing 'tls.crt' of Secret \"test-tls\": error decoding certificate PEM block\n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := (&CertificateStatus{SecretName: "test-tls", Issuing: test.issuing}).
+				withSecret(test.secret, nil, test.err, fakeclock.NewFakeClock(time.Now()))
+			if !assert.Error(t, status.SecretStatus.Error) {
+				return
+			}
+			assert.Equal(t, test.expNotCreated, status.SecretStatus.NotCreated)
+			assert.Equal(t, test.expNotCreated, apierrors.IsNotFound(status.SecretStatus.Error))
+			assert.Equal(t, test.expOutput, status.SecretStatus.String())
+		})
+	}
+}
+
+func TestCreatedAtAndLastIssued(t *testing.T) {
+	now := time.Date(2020, 9, 16, 0, 0, 0, 0, time.UTC)
+	revision := 2
+	notCreatedErr := &secretNotCreatedError{name: "test-tls", err: apierrors.NewNotFound(corev1.Resource("secrets"), "test-tls")}
+	tests := map[string]struct {
+		status       *CertificateStatus
+		expCreatedAt string
+		expIssued    string
+	}{
+		"never issued": {
+			status:       &CertificateStatus{CreationTime: metav1.NewTime(now.Add(-time.Hour)), ObservedAt: now},
+			expCreatedAt: "2020-09-15T23:00:00Z (60m ago)",
+			expIssued:    "never, no revision of the Certificate has been issued yet",
+		},
+		"issued, Secret read": {
+			status: &CertificateStatus{CreationTime: metav1.NewTime(now.Add(-30 * 24 * time.Hour)), ObservedAt: now, Revision: &revision,
+				SecretStatus: &SecretStatus{NotBefore: now.Add(-72 * time.Hour)}},
+			expCreatedAt: "2020-08-17T00:00:00Z (30d ago)",
+			expIssued:    "3d ago (revision 2, 2020-09-13T00:00:00Z)",
+		},
+		"issued, Secret deleted since": {
+			status: &CertificateStatus{CreationTime: metav1.NewTime(now.Add(-30 * 24 * time.Hour)), ObservedAt: now, Revision: &revision,
+				SecretStatus: &SecretStatus{Error: notCreatedErr, NotCreated: true}},
+			expCreatedAt: "2020-08-17T00:00:00Z (30d ago)",
+			expIssued:    "unknown, revision 2 was issued but the certificate in the Secret could not be read",
+		},
+		"time of the status not known": {
+			status: &CertificateStatus{CreationTime: metav1.NewTime(now.Add(-time.Hour)), Revision: &revision,
+				SecretStatus: &SecretStatus{NotBefore: now.Add(-72 * time.Hour)}},
+			expCreatedAt: "2020-09-15T23:00:00Z",
+			expIssued:    "2020-09-13T00:00:00Z (revision 2)",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expCreatedAt, formatCreatedAt(test.status.CreationTime, test.status.ObservedAt))
+			assert.Equal(t, test.expIssued, test.status.formatLastIssued())
+		})
+	}
+}
+
+func TestStableOutputOrder(t *testing.T) {
+	secretStatus := &SecretStatus{Name: "test-tls", SerialNumber: big.NewInt(1), DNSNames: []string{"www.example.com", "api.example.com"},
+		IssuerOrganisation: []string{"Org B", "Org A"}, OCSPServer: []string{"http://ocsp.b.example", "http://ocsp.a.example"}}
+	status := &CertificateStatus{Conditions: []cmapi.CertificateCondition{
+		{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue},
+		{Type: "Custom", Status: cmmeta.ConditionFalse},
+		{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue},
+	}, SecretStatus: secretStatus}
+
+	var buf bytes.Buffer
+	writeCertificateConditions(&statusWriter{w: &buf}, status.Conditions)
+	assert.Equal(t, `  Ready: True, Reason: , Message: 
+  Custom: False, Reason: , Message: 
+  Issuing: True, Reason: , Message: 
+`, buf.String())
+
+	output := secretStatus.String()
+	assert.Contains(t, output, "  Issuer Organisation: Org A, Org B\n")
+	assert.Contains(t, output, "    DNS Names: api.example.com, www.example.com\n")
+	assert.Contains(t, output, "    OCSP Servers: http://ocsp.a.example, http://ocsp.b.example\n")
+
+	// Only the text output is sorted, the status and its JSON keep the order the values were parsed in
+	assert.Equal(t, []string{"www.example.com", "api.example.com"}, secretStatus.DNSNames)
+	assert.Equal(t, cmapi.CertificateConditionIssuing, status.Conditions[0].Type)
+}
+
+func TestWithRenewalState(t *testing.T) {
+	now := time.Date(2020, 9, 16, 0, 0, 0, 0, time.UTC)
+	renewalTime := func(d time.Duration) *metav1.Time { return &metav1.Time{Time: now.Add(d)} }
+	validSecret := &SecretStatus{NotAfter: now.Add(30 * 24 * time.Hour)}
+	expiredSecret := &SecretStatus{NotAfter: now.Add(-time.Hour)}
+	tests := map[string]struct {
+		status    *CertificateStatus
+		expState  string
+		expOutput string
+	}{
+		"before the Renewal Time": {
+			status:    &CertificateStatus{RenewalTime: renewalTime(24 * time.Hour), SecretStatus: validSecret},
+			expState:  renewalStateOK,
+			expOutput: "Renewal State: OK\n",
+		},
+		"past the Renewal Time and being reissued": {
+			status:    &CertificateStatus{RenewalTime: renewalTime(-time.Hour), Issuing: true, SecretStatus: validSecret},
+			expState:  renewalStateDueForRenewal,
+			expOutput: "Renewal State: DueForRenewal, the Renewal Time has passed and the Certificate is being reissued\n",
+		},
+		"past the Renewal Time and not being reissued": {
+			status:    &CertificateStatus{RenewalTime: renewalTime(-time.Hour), SecretStatus: validSecret},
+			expState:  renewalStateOverdue,
+			expOutput: "Renewal State: Overdue, the Certificate should have been renewed but is not being reissued or its certificate has expired\n",
+		},
+		"certificate expired while being reissued": {
+			status:   &CertificateStatus{RenewalTime: renewalTime(-2 * time.Hour), Issuing: true, SecretStatus: expiredSecret},
+			expState: renewalStateOverdue,
+		},
+		"certificate expired without a Renewal Time": {
+			status:   &CertificateStatus{SecretStatus: expiredSecret},
+			expState: renewalStateOverdue,
+		},
+		"unknown without a Renewal Time": {
+			status: &CertificateStatus{SecretStatus: validSecret},
+		},
+		"unknown without a Renewal Time or Secret": {
+			status: &CertificateStatus{SecretStatus: &SecretStatus{Error: errors.New("error when finding Secret")}},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := test.status.withRenewalState(fakeclock.NewFakeClock(now))
+			assert.Equal(t, test.expState, status.RenewalState)
+
+			var buf bytes.Buffer
+			status.writeRenewalState(&statusWriter{w: &buf})
+			if test.expOutput != "" || test.expState == "" {
+				assert.Equal(t, test.expOutput, buf.String())
+			}
+		})
+	}
+}
+
+func TestWithSecretDuration(t *testing.T) {
+	notBefore := time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		requested      time.Duration
+		actual         time.Duration
+		expShortened   bool
+		expOutputLines []string
+	}{
+		"issuer shortened the duration": {
+			requested:      365 * 24 * time.Hour,
+			actual:         90 * 24 * time.Hour,
+			expShortened:   true,
+			expOutputLines: []string{"Requested Duration: 365d", "Actual Duration: 90d"},
+		},
+		"Not After a second early is not shortened": {
+			requested:      90 * 24 * time.Hour,
+			actual:         90*24*time.Hour - time.Second,
+			expShortened:   false,
+			expOutputLines: []string{"Requested Duration: 90d", "Actual Duration: 89d"},
+		},
+		"longer than requested": {
+			requested:      24 * time.Hour,
+			actual:         48 * time.Hour,
+			expShortened:   false,
+			expOutputLines: []string{"Requested Duration: 24h", "Actual Duration: 2d"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			certPEM, _ := generateCertPEM(t, &x509.Certificate{SerialNumber: big.NewInt(1),
+				Subject: pkix.Name{CommonName: "example.com"}, NotBefore: notBefore, NotAfter: notBefore.Add(test.actual)}, nil, nil)
+			secret := gen.Secret("test-tls", gen.SetSecretData(map[string][]byte{"tls.crt": certPEM}))
+
+			status := (&CertificateStatus{RequestedDuration: newDurationStatus(test.requested)}).
+				withSecret(secret, nil, nil, fakeclock.NewFakeClock(notBefore))
+			if !assert.NoError(t, status.SecretStatus.Error) {
+				return
+			}
+			assert.Equal(t, newDurationStatus(test.actual), status.ActualDuration)
+			assert.Equal(t, test.expShortened, status.DurationShortened)
+
+			var buf bytes.Buffer
+			status.writeValidity(&statusWriter{w: &buf})
+			for _, line := range test.expOutputLines {
+				assert.Contains(t, buf.String(), line+"\n")
+			}
+		})
+	}
+}
+
+func TestWeakSignatureAlgorithm(t *testing.T) {
+	tests := map[string]struct {
+		secretStatus *SecretStatus
+		expWarning   bool
+	}{
+		"SHA-256": {
+			secretStatus: &SecretStatus{SignatureAlgorithm: x509.SHA256WithRSA},
+			expWarning:   false,
+		},
+		"SHA-1": {
+			secretStatus: &SecretStatus{SignatureAlgorithm: x509.SHA1WithRSA},
+			expWarning:   true,
+		},
+		"ECDSA with SHA-1": {
+			secretStatus: &SecretStatus{SignatureAlgorithm: x509.ECDSAWithSHA1},
+			expWarning:   true,
+		},
+		"MD5 already reported as below the signature policy": {
+			secretStatus: &SecretStatus{SignatureAlgorithm: x509.MD5WithRSA,
+				SignaturePolicy: &SignaturePolicyStatus{Strength: "weak", MinStrength: "sha256"}},
+			expWarning: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			warnings := (&CertificateStatus{SecretStatus: test.secretStatus}).warnings()
+			if test.expWarning {
+				assert.Contains(t, warnings, "the signature algorithm is weak and deprecated")
+			} else {
+				assert.NotContains(t, warnings, "the signature algorithm is weak and deprecated")
+			}
+			assert.Equal(t, test.expWarning, test.secretStatus.weakSignatureAlgorithm())
+		})
+	}
+}
+
+func TestLookupContext(t *testing.T) {
+	o := &Options{LookupTimeout: time.Minute}
+	ctx, cancel := o.lookupContext(context.TODO())
+	defer cancel()
+	if deadline, ok := ctx.Deadline(); assert.True(t, ok, "expected a deadline when --lookup-timeout is set") {
+		assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+	}
+
+	o = &Options{}
+	ctx, cancel = o.lookupContext(context.TODO())
+	_, ok := ctx.Deadline()
+	assert.False(t, ok, "expected no deadline when --lookup-timeout is zero")
+	cancel()
+	assert.Error(t, ctx.Err(), "expected the context to be cancelled")
+}
+
+func TestSearchEvents(t *testing.T) {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test-tls", Namespace: "ns1", UID: "uid-1"}}
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "test-tls.1", Namespace: "ns1"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Secret", Name: "test-tls", Namespace: "ns1", UID: "uid-1"},
+	}
+	clientSet := fakekubernetes.NewSimpleClientset(event)
+
+	events, err := searchEvents(context.TODO(), clientSet, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, events.Items, 1) {
+		assert.Equal(t, "test-tls.1", events.Items[0].Name)
+	}
+
+	// The fake clientset does not filter by field selector, so only check that events are listed in the namespace of secret
+	actions := clientSet.Actions()
+	if assert.NotEmpty(t, actions) {
+		listAction, ok := actions[len(actions)-1].(coretesting.ListAction)
+		if assert.True(t, ok, "expected the last action to be a list, got %v", actions[len(actions)-1]) {
+			assert.Equal(t, "ns1", listAction.GetNamespace())
+		}
+	}
+}
+
+func TestShowEvents(t *testing.T) {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test-tls", Namespace: "ns1", UID: "uid-1"}}
+	clientSet := fakekubernetes.NewSimpleClientset()
+
+	events, err := (&Options{ShowEvents: false}).findEvents(context.TODO(), clientSet, secret)
+	assert.NoError(t, err)
+	assert.Nil(t, events)
+	assert.Empty(t, clientSet.Actions(), "expected no events to be looked up")
+
+	status := &CertificateStatus{Name: "test-crt", Namespace: "ns1",
+		IssuerStatus: &IssuerStatus{Name: "test-issuer", Kind: "Issuer"},
+		SecretStatus: &SecretStatus{Error: errors.New("error when finding Secret")},
+		CRStatus:     &CRStatus{Name: "test-crt-1"}}
+	assert.Contains(t, writerToString(status), "Events:")
+	assert.NotContains(t, writerToString(status.withEventsShown(false)), "Events:")
+}
+
+func TestWithEventTypeFilter(t *testing.T) {
+	events := func() *corev1.EventList {
+		return &corev1.EventList{Items: []corev1.Event{
+			{Type: corev1.EventTypeNormal, Reason: "Issuing", Message: "Issuing certificate as Secret does not exist"},
+			{Type: corev1.EventTypeWarning, Reason: "Failed", Message: "The certificate request has failed to complete"},
+			{Type: corev1.EventTypeNormal, Reason: "Requested", Message: "Created new CertificateRequest resource"},
+		}}
+	}
+	tests := map[string]struct {
+		eventType     string
+		expReasons    []string
+		expSuppressed int
+		expHeader     string
+	}{
+		"all event types": {
+			eventType:  eventTypeAll,
+			expReasons: []string{"Issuing", "Failed", "Requested"},
+			expHeader:  "  Events:\n",
+		},
+		"Warning events only": {
+			eventType:     corev1.EventTypeWarning,
+			expReasons:    []string{"Failed"},
+			expSuppressed: 2,
+			expHeader:     "  Events (2 suppressed by --event-type):\n",
+		},
+		"Normal events only": {
+			eventType:     corev1.EventTypeNormal,
+			expReasons:    []string{"Issuing", "Requested"},
+			expSuppressed: 1,
+			expHeader:     "  Events (1 suppressed by --event-type):\n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := (&CertificateStatus{SecretStatus: &SecretStatus{Events: events()}}).withEventTypeFilter(test.eventType)
+			var reasons []string
+			for _, event := range status.SecretStatus.Events.Items {
+				reasons = append(reasons, event.Reason)
+			}
+			assert.Equal(t, test.expReasons, reasons)
+			assert.Equal(t, test.expSuppressed, status.SecretStatus.SuppressedEvents)
+
+			var buf bytes.Buffer
+			writeEvents(&buf, status.SecretStatus.Events, status.SecretStatus.SuppressedEvents, 1, time.Time{})
+			assert.True(t, strings.HasPrefix(buf.String(), test.expHeader), "unexpected header in:\n%s", buf.String())
+		})
+	}
+
+	var buf bytes.Buffer
+	noWarnings := &corev1.EventList{Items: events().Items[:1]}
+	filtered, suppressed := filterEvents(noWarnings, corev1.EventTypeWarning)
+	writeEvents(&buf, filtered, suppressed, 0, time.Time{})
+	assert.Equal(t, "Events (1 suppressed by --event-type):\t<none>\n", buf.String())
+}
+
+func TestWriteToColor(t *testing.T) {
+	status := &CertificateStatus{
+		Name: "test-crt",
+		Conditions: []cmapi.CertificateCondition{
+			{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse, Reason: "Failed", Message: "example"},
+			{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue, Reason: "Issuing", Message: "example"},
+		},
+		SpecMatchesIssued: new(bool),
+		IssuerStatus: &IssuerStatus{Name: "ca-issuer", Kind: "Issuer", Conditions: []cmapi.IssuerCondition{
+			{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue, Message: "example"},
+		}},
+		SecretStatus: &SecretStatus{Error: errors.New("error when finding Secret \"test-tls\"\n")},
+		CRStatus:     &CRStatus{Error: errors.New("No CertificateRequest found for this Certificate\n")},
+	}
+
+	var buf bytes.Buffer
+	if _, err := status.WriteTo(colorWriter{&buf}); err != nil {
+		t.Fatal(err)
+	}
+	output := buf.String()
+	for _, expLine := range []string{
+		"  " + colorRed + "Ready: False" + colorReset + ", Reason: Failed, Message: example\n",
+		"  Issuing: True, Reason: Issuing, Message: example\n",
+		"  " + colorYellow + "warning: the DNS Names differ from those of the certificate in the Secret (DNSNamesMismatch)" + colorReset + "\n",
+		"    " + colorGreen + "Ready: True" + colorReset + ", Reason: , Message: example\n",
+	} {
+		assert.Contains(t, output, expLine)
+	}
+
+	buf.Reset()
+	if _, err := status.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	assert.NotContains(t, buf.String(), "\x1b[", "expected no escape codes without color")
+}
+
+func TestSummary(t *testing.T) {
+	readyCond := cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}
+	notReadyCond := cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse}
+	tests := map[string]struct {
+		status     *CertificateStatus
+		expSummary string
+	}{
+		"ready with Secret and Issuer": {
+			status: &CertificateStatus{Name: "web-tls", Namespace: "prod", Conditions: []cmapi.CertificateCondition{readyCond},
+				IssuerStatus: &IssuerStatus{Name: "letsencrypt-prod", Kind: "ClusterIssuer"},
+				SecretStatus: &SecretStatus{ExpiresIn: 29 * 24 * time.Hour}},
+			expSummary: "web-tls (prod): Ready, expires in 29d via letsencrypt-prod",
+		},
+		"not ready with expired certificate": {
+			status: &CertificateStatus{Name: "web-tls", Namespace: "prod", Conditions: []cmapi.CertificateCondition{notReadyCond},
+				IssuerStatus: &IssuerStatus{Name: "ca-issuer", Kind: "Issuer"},
+				SecretStatus: &SecretStatus{ExpiresIn: -3 * 24 * time.Hour, Expired: true}},
+			expSummary: "web-tls (prod): Not Ready, expired 3d ago via ca-issuer",
+		},
+		"no Ready condition, Secret and Issuer not found": {
+			status: &CertificateStatus{Name: "web-tls", Namespace: "prod",
+				IssuerStatus: &IssuerStatus{Error: errors.New("error when getting Issuer")},
+				SecretStatus: &SecretStatus{Error: errors.New("error when finding Secret")}},
+			expSummary: "web-tls (prod): Ready unknown, expiry unknown",
+		},
+		"nil Secret and Issuer status": {
+			status:     &CertificateStatus{Name: "web-tls", Namespace: "prod", Conditions: []cmapi.CertificateCondition{readyCond}},
+			expSummary: "web-tls (prod): Ready, expiry unknown",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expSummary, test.status.Summary())
+		})
+	}
+}
+
+func TestWriteValidity(t *testing.T) {
+	now := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	notBefore := &metav1.Time{Time: now.Add(-20 * 24 * time.Hour)}
+	notAfter := &metav1.Time{Time: now.Add(10 * 24 * time.Hour)}
+	tests := map[string]struct {
+		notBefore, notAfter, renewalTime *metav1.Time
+		expOutput                        string
+		expWarnings                      []string
+	}{
+		"renewal in the future": {
+			notBefore:   notBefore,
+			notAfter:    notAfter,
+			renewalTime: &metav1.Time{Time: now.Add(48 * time.Hour)},
+			expOutput: `Not Before: 2020-05-12T00:00:00Z (20d ago)
+Not After: 2020-06-11T00:00:00Z (in 10d)
+Renewal Time: cert-manager will renew at 2020-06-03T00:00:00Z (in 2d)
+`,
+		},
+		"renewal overdue": {
+			notBefore:   notBefore,
+			notAfter:    notAfter,
+			renewalTime: &metav1.Time{Time: now.Add(-24 * time.Hour)},
+			expOutput: `Not Before: 2020-05-12T00:00:00Z (20d ago)
+Not After: 2020-06-11T00:00:00Z (in 10d)
+Renewal Time: cert-manager was due to renew at 2020-05-31T00:00:00Z (24h ago)
+`,
+			expWarnings: []string{"the Renewal Time has passed"},
+		},
+		"renewal after expiry": {
+			notBefore:   notBefore,
+			notAfter:    notAfter,
+			renewalTime: &metav1.Time{Time: now.Add(15 * 24 * time.Hour)},
+			expOutput: `Not Before: 2020-05-12T00:00:00Z (20d ago)
+Not After: 2020-06-11T00:00:00Z (in 10d)
+Renewal Time: cert-manager will renew at 2020-06-16T00:00:00Z (in 15d)
+`,
+			expWarnings: []string{"the Renewal Time is after Not After"},
+		},
+		"renewal time without Not After": {
+			renewalTime: &metav1.Time{Time: now.Add(48 * time.Hour)},
+			expOutput: `Not Before: not set
+Not After: not set
+Renewal Time: cert-manager will renew at 2020-06-03T00:00:00Z (in 2d)
+`,
+		},
+		"nothing set": {
+			expOutput: `Not Before: not set
+Not After: not set
+Renewal Time: not set
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := &CertificateStatus{NotBefore: test.notBefore, NotAfter: test.notAfter, RenewalTime: test.renewalTime,
+				Validity: newValidityStatus(test.notBefore, test.notAfter, test.renewalTime, now)}
+			var buf bytes.Buffer
+			status.writeValidity(&statusWriter{w: &buf})
+			assert.Equal(t, test.expOutput, buf.String())
+			assert.Equal(t, test.expWarnings, status.warnings())
+		})
+	}
+}
+
+func TestSecretFromFile(t *testing.T) {
+	certPEM, sk := generateCertPEM(t, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC),
+	}, nil, nil)
+	keyPEM, err := pki.EncodePrivateKey(sk, cmapi.PKCS8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secretManifest := fmt.Sprintf(`apiVersion: v1
+kind: Secret
+metadata:
+  name: test-tls
+type: kubernetes.io/tls
+data:
+  tls.crt: %s
+`, base64.StdEncoding.EncodeToString(certPEM))
+
+	tests := map[string]struct {
+		fileData  string
+		expSecret *corev1.Secret
+		expPEM    bool
+		expErr    string
+	}{
+		"PEM file with a private key before the certificate": {
+			fileData:  string(keyPEM) + string(certPEM),
+			expSecret: &corev1.Secret{Data: map[string][]byte{"tls.crt": certPEM, "tls.key": keyPEM}},
+			expPEM:    true,
+		},
+		"Secret manifest": {
+			fileData: secretManifest,
+			expSecret: &corev1.Secret{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+				ObjectMeta: metav1.ObjectMeta{Name: "test-tls"}, Type: corev1.SecretTypeTLS,
+				Data: map[string][]byte{"tls.crt": certPEM}},
+		},
+		"Secret manifest with stringData": {
+			fileData: "kind: Secret\nmetadata:\n  name: test-tls\nstringData:\n  tls.crt: |\n    " +
+				strings.ReplaceAll(strings.TrimSpace(string(certPEM)), "\n", "\n    ") + "\n",
+			expSecret: &corev1.Secret{TypeMeta: metav1.TypeMeta{Kind: "Secret"}, ObjectMeta: metav1.ObjectMeta{Name: "test-tls"},
+				StringData: map[string]string{"tls.crt": string(certPEM)}, Data: map[string][]byte{"tls.crt": certPEM}},
+		},
+		"manifest of another kind": {
+			fileData: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n",
+			expErr:   `error: file "test-file" holds a ConfigMap, not a Secret`,
+		},
+		"PEM file without a certificate": {
+			fileData: string(keyPEM),
+			expPEM:   true,
+			expErr:   `error: file "test-file" is neither a Secret manifest nor holds a PEM encoded certificate`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			secret, isPEM, err := secretFromFile("test-file", []byte(test.fileData))
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expSecret, secret)
+			assert.Equal(t, test.expPEM, isPEM)
+		})
+	}
+}
+
+func TestStatusFromFile(t *testing.T) {
+	certPEM, _ := generateCertPEM(t, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC),
+	}, nil, nil)
+	clock := fakeclock.NewFakeClock(time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := map[string]struct {
+		secret    *corev1.Secret
+		isPEM     bool
+		expHeader string
+	}{
+		"PEM file": {
+			secret:    &corev1.Secret{Data: map[string][]byte{"tls.crt": certPEM}},
+			isPEM:     true,
+			expHeader: "Certificate:\n  File: tls.crt\n  Issuer Country: \n",
+		},
+		"Secret manifest annotated with the name of a Certificate": {
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test-tls",
+				Annotations: map[string]string{cmapi.CertificateNameKey: "test-crt"}},
+				Type: corev1.SecretTypeTLS, Data: map[string][]byte{"tls.crt": certPEM}},
+			expHeader: "Secret:\n  Name: test-tls\n  Type: kubernetes.io/tls\n  File: tls.crt\n  Issuer Country: \n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := &Options{FromFile: "tls.crt", FingerprintAlgorithm: fingerprintAlgorithmSHA256}
+			secretStatus := o.statusFromFile(test.secret, test.isPEM, clock)
+			if secretStatus.Error != nil {
+				t.Fatal(secretStatus.Error)
+			}
+			assert.False(t, secretStatus.CertificateNameMismatch, "expected no Certificate to compare the annotation with")
+			assert.Equal(t, "example.com", secretStatus.SubjectCommonName)
+
+			var buf bytes.Buffer
+			if _, err := secretStatus.WriteTo(&buf); err != nil {
+				t.Fatal(err)
+			}
+			assert.True(t, strings.HasPrefix(buf.String(), test.expHeader), "expected output to start with %q, got %q", test.expHeader, buf.String())
+			assert.NotContains(t, buf.String(), "Owned by Certificate")
+		})
+	}
+}
+
+func TestIssuerRefGroup(t *testing.T) {
+	tests := map[string]struct {
+		group       string
+		expGroup    string
+		expExternal bool
+	}{
+		"empty group defaults to the API group": {group: "", expGroup: "cert-manager.io", expExternal: false},
+		"API group":                             {group: "cert-manager.io", expGroup: "cert-manager.io", expExternal: false},
+		"group of an external issuer":           {group: "awspca.cert-manager.io", expGroup: "awspca.cert-manager.io", expExternal: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			group, external := issuerRefGroup(cmmeta.ObjectReference{Name: "test-issuer", Group: test.group}, "cert-manager.io")
+			assert.Equal(t, test.expGroup, group)
+			assert.Equal(t, test.expExternal, external)
+		})
+	}
+}
+
+func TestIssuerTypeAndEndpoint(t *testing.T) {
+	tests := map[string]struct {
+		spec        cmapi.IssuerSpec
+		expType     string
+		expEndpoint string
+	}{
+		"ACME": {
+			spec:    cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{ACME: &cmacmev1.ACMEIssuer{Server: "https://acme-v02.api.letsencrypt.org/directory"}}},
+			expType: "ACME", expEndpoint: "https://acme-v02.api.letsencrypt.org/directory",
+		},
+		"CA": {
+			spec:    cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{CA: &cmapi.CAIssuer{SecretName: "ca-key-pair"}}},
+			expType: "CA", expEndpoint: `Secret "ca-key-pair"`,
+		},
+		"Vault": {
+			spec:    cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{Vault: &cmapi.VaultIssuer{Server: "https://vault.local:8200", Path: "pki/sign/example"}}},
+			expType: "Vault", expEndpoint: "https://vault.local:8200, path pki/sign/example",
+		},
+		"Venafi TPP": {
+			spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{Venafi: &cmapi.VenafiIssuer{Zone: `devops\\cert-manager`,
+				TPP: &cmapi.VenafiTPP{URL: "https://tpp.local/vedsdk"}}}},
+			expType: "Venafi", expEndpoint: `TPP https://tpp.local/vedsdk, zone devops\\cert-manager`,
+		},
+		"Venafi Cloud at the default URL": {
+			spec:    cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{Venafi: &cmapi.VenafiIssuer{Zone: "Default", Cloud: &cmapi.VenafiCloud{}}}},
+			expType: "Venafi", expEndpoint: "Venafi Cloud, zone Default",
+		},
+		"SelfSigned has no endpoint": {
+			spec:    cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{SelfSigned: &cmapi.SelfSignedIssuer{}}},
+			expType: "SelfSigned",
+		},
+		"no config known to the command": {},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuerType, endpoint := issuerTypeAndEndpoint(&test.spec)
+			assert.Equal(t, test.expType, issuerType)
+			assert.Equal(t, test.expEndpoint, endpoint)
+		})
+	}
+
+	status := (&CertificateStatus{}).withGenericIssuer(gen.Issuer("test-issuer"), "Issuer", nil, nil, fakeclock.NewFakeClock(time.Now()))
+	assert.Contains(t, status.IssuerStatus.String(), "  Type: unknown issuer type\n")
+}
+
+func TestWithIssuerRef(t *testing.T) {
+	ref := cmmeta.ObjectReference{Name: "test-issuer", Kind: "AWSPCAIssuer", Group: "awspca.cert-manager.io"}
+	tests := map[string]struct {
+		status    *CertificateStatus
+		group     string
+		external  bool
+		expIssuer *IssuerStatus
+		expOutput string
+	}{
+		"external issuer is recorded without being looked up": {
+			status:    &CertificateStatus{},
+			group:     "awspca.cert-manager.io",
+			external:  true,
+			expIssuer: &IssuerStatus{Name: "test-issuer", Kind: "AWSPCAIssuer", Group: "awspca.cert-manager.io", External: true},
+			expOutput: `Issuer:
+  Name: test-issuer
+  Kind: AWSPCAIssuer
+  Group: awspca.cert-manager.io
+  Note: this is an external issuer, which is not looked up. To get more information about it, try 'kubectl describe awspcaissuer.awspca.cert-manager.io test-issuer'
+`,
+		},
+		"group of an Issuer of cert-manager": {
+			status:    &CertificateStatus{IssuerStatus: &IssuerStatus{Name: "ca-issuer", Kind: "Issuer", Type: "CA", Endpoint: `Secret "ca-key-pair"`}},
+			group:     "cert-manager.io",
+			expIssuer: &IssuerStatus{Name: "ca-issuer", Kind: "Issuer", Group: "cert-manager.io", Type: "CA", Endpoint: `Secret "ca-key-pair"`},
+			expOutput: `Issuer:
+  Name: ca-issuer
+  Kind: Issuer
+  Group: cert-manager.io
+  Type: CA
+  Endpoint: Secret "ca-key-pair"
+  Conditions:
+    No Conditions set
+  Events:  <none>
+`,
+		},
+		"Issuer that could not be found": {
+			status:    &CertificateStatus{IssuerStatus: &IssuerStatus{Error: errors.New("error when getting Issuer: not found\n")}},
+			group:     "cert-manager.io",
+			expIssuer: &IssuerStatus{Error: errors.New("error when getting Issuer: not found\n")},
+			expOutput: "error when getting Issuer: not found\n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := test.status.withIssuerRef(ref, test.group, test.external)
+			assert.Equal(t, test.expIssuer, status.IssuerStatus)
+			assert.Equal(t, test.expOutput, status.IssuerStatus.String())
+		})
+	}
+}
+
+func TestWriteRefreshJSONLines(t *testing.T) {
+	status := &CertificateStatus{
+		Name:         "test-crt",
+		Namespace:    "testns",
+		CreationTime: metav1.NewTime(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)),
+		IssuerStatus: &IssuerStatus{Error: errors.New("error when getting Issuer: not found\n")},
+		SecretStatus: &SecretStatus{Name: "test-tls", Error: errors.New("error when finding Secret \"test-tls\": not found\n")},
+	}
+	o := &Options{Output: "jsonl"}
+	var buf bytes.Buffer
+	out := bufio.NewWriter(&buf)
+	for _, now := range []time.Time{time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 7, 1, 0, 0, 2, 0, time.UTC)} {
+		if err := o.writeRefresh(out, status, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if assert.Len(t, lines, 2, "expected each refresh to be flushed as a single line") {
+		// The Issuer and Secret could not be looked up, so only their errors are written
+		assert.JSONEq(t, `{
+  "timestamp": "2020-07-01T00:00:02Z",
+  "name": "test-crt",
+  "namespace": "testns",
+  "creationTime": "2020-06-01T00:00:00Z",
+  "issuer": {"error": "error when getting Issuer: not found"},
+  "secret": {"name": "test-tls", "error": "error when finding Secret \"test-tls\": not found"}
+}`, lines[1])
+	}
+}
+
+func TestWithKeystores(t *testing.T) {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC),
+	}
+	certPEM, sk := generateCertPEM(t, template, nil, nil)
+	otherCertPEM, otherSK := generateCertPEM(t, template, nil, nil)
+	encodePKCS12 := func(certPEM []byte, sk crypto.Signer) []byte {
+		cert, err := pki.DecodeX509CertificateBytes(certPEM)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keystore, err := pkcs12.Encode(rand.Reader, sk, cert, nil, "password")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return keystore
+	}
+	keystore, otherKeystore := encodePKCS12(certPEM, sk), encodePKCS12(otherCertPEM, otherSK)
+	matches, differs := true, false
+
+	tests := map[string]struct {
+		data         map[string][]byte
+		password     string
+		expKeystores *KeystoresStatus
+		expOutput    string
+	}{
+		"no keystores": {
+			data:         map[string][]byte{},
+			password:     "password",
+			expKeystores: nil,
+		},
+		"keystores without a password": {
+			data:         map[string][]byte{"keystore.p12": keystore, "keystore.jks": []byte("jks")},
+			expKeystores: &KeystoresStatus{PKCS12Size: len(keystore), JKSSize: 3},
+			expOutput:    fmt.Sprintf("  Keystores:\n    keystore.p12: %d bytes\n    keystore.jks: 3 bytes\n", len(keystore)),
+		},
+		"PKCS#12 keystore holding the certificate": {
+			data:         map[string][]byte{"keystore.p12": keystore},
+			password:     "password",
+			expKeystores: &KeystoresStatus{PKCS12Size: len(keystore), PKCS12MatchesCert: &matches},
+			expOutput:    fmt.Sprintf("  Keystores:\n    keystore.p12: %d bytes, holds the certificate in 'tls.crt'\n", len(keystore)),
+		},
+		"PKCS#12 keystore holding another certificate": {
+			data:         map[string][]byte{"keystore.p12": otherKeystore},
+			password:     "password",
+			expKeystores: &KeystoresStatus{PKCS12Size: len(otherKeystore), PKCS12MatchesCert: &differs},
+			expOutput: fmt.Sprintf("  Keystores:\n    keystore.p12: %d bytes, does not hold the certificate in 'tls.crt'\n",
+				len(otherKeystore)),
+		},
+		"wrong password": {
+			data:         map[string][]byte{"keystore.p12": keystore},
+			password:     "wrong",
+			expKeystores: &KeystoresStatus{PKCS12Size: len(keystore), PKCS12Error: "pkcs12: decryption password incorrect"},
+			expOutput: fmt.Sprintf("  Keystores:\n    keystore.p12: %d bytes, could not be decoded with the password given\n",
+				len(keystore)),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.data["tls.crt"] = certPEM
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test-tls"}, Data: test.data}
+			status := (&CertificateStatus{}).withSecret(secret, nil, nil, fakeclock.NewFakeClock(time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC))).
+				withPKCS12Verification(secret, test.password)
+			assert.Equal(t, test.expKeystores, status.SecretStatus.Keystores)
+			if test.expKeystores != nil {
+				assert.Equal(t, test.expOutput, status.SecretStatus.Keystores.String())
+			}
+		})
+	}
+}
+
+func TestFindOwnedCRs(t *testing.T) {
+	crt := gen.Certificate("test-crt", gen.SetCertificateNamespace("ns1"), gen.SetCertificateUID("crt-uid"),
+		gen.SetCertificateRevision(2))
+	ownerRef := *metav1.NewControllerRef(crt, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateKind))
+	crWithRevision := func(name, revision string, owned bool) runtime.Object {
+		mods := []gen.CertificateRequestModifier{gen.SetCertificateRequestNamespace("ns1")}
+		if revision != "" {
+			mods = append(mods, gen.SetCertificateRequestAnnotations(map[string]string{cmapi.CertificateRequestRevisionAnnotationKey: revision}))
+		}
+		if owned {
+			mods = append(mods, gen.AddCertificateRequestOwnerReferences(ownerRef))
+		}
+		return gen.CertificateRequest(name, mods...)
+	}
+	client := typedResourceClient{cmClient: cmfake.NewSimpleClientset(
+		crWithRevision("test-crt-1", "1", true),
+		crWithRevision("test-crt-3", "3", true),
+		crWithRevision("test-crt-2", "2", true),
+		crWithRevision("other-crt-4", "4", false),
+		crWithRevision("test-crt-unannotated", "", true),
+	)}
+
+	reqs, err := findOwnedCRs(client, context.TODO(), crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, req := range reqs {
+		names = append(names, req.Name)
+	}
+	assert.Equal(t, []string{"test-crt-3", "test-crt-2", "test-crt-1"}, names)
+
+	req, err := findMatchingCR(reqs, crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "test-crt-3", req.Name)
+}
+
+func TestRetryLookup(t *testing.T) {
+	defer func(backoff wait.Backoff) { lookupBackoff = backoff }(lookupBackoff)
+	lookupBackoff = wait.Backoff{Steps: 4, Duration: time.Millisecond}
+
+	secretsResource := corev1.Resource("secrets")
+	tests := map[string]struct {
+		errs        []error
+		cancelled   bool
+		expAttempts int
+		expErr      bool
+	}{
+		"succeeds at once": {
+			expAttempts: 1,
+		},
+		"service unavailable is retried": {
+			errs:        []error{apierrors.NewServiceUnavailable("etcd is unavailable")},
+			expAttempts: 2,
+		},
+		"conflicts and server timeouts are retried": {
+			errs:        []error{apierrors.NewConflict(secretsResource, "test-tls", errors.New("conflict")), apierrors.NewServerTimeout(secretsResource, "get", 1)},
+			expAttempts: 3,
+		},
+		"network timeouts are retried": {
+			errs:        []error{fmt.Errorf("error when finding Secret: %w", &net.DNSError{Err: "i/o timeout", IsTimeout: true})},
+			expAttempts: 2,
+		},
+		"NotFound is not retried": {
+			errs:        []error{apierrors.NewNotFound(secretsResource, "test-tls")},
+			expAttempts: 1,
+			expErr:      true,
+		},
+		"Forbidden is not retried": {
+			errs:        []error{apierrors.NewForbidden(secretsResource, "test-tls", errors.New("denied"))},
+			expAttempts: 1,
+			expErr:      true,
+		},
+		"retries are bounded": {
+			errs: []error{apierrors.NewInternalError(errors.New("1")), apierrors.NewInternalError(errors.New("2")),
+				apierrors.NewInternalError(errors.New("3")), apierrors.NewInternalError(errors.New("4")), nil},
+			expAttempts: 4,
+			expErr:      true,
+		},
+		"no retries once the context is done": {
+			errs:        []error{apierrors.NewServiceUnavailable("etcd is unavailable")},
+			cancelled:   true,
+			expAttempts: 1,
+			expErr:      true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+			if test.cancelled {
+				cancel()
+			}
+			calls := 0
+			attempts, err := retryLookup(ctx, func() error {
+				calls++
+				if calls <= len(test.errs) {
+					return test.errs[calls-1]
+				}
+				return nil
+			})
+			assert.Equal(t, test.expAttempts, attempts)
+			assert.Equal(t, test.expAttempts, calls)
+			assert.Equal(t, test.expErr, err != nil, "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestGetResourcesForCertificateRetries(t *testing.T) {
+	defer func(backoff wait.Backoff) { lookupBackoff = backoff }(lookupBackoff)
+	lookupBackoff = wait.Backoff{Steps: 4, Duration: time.Millisecond}
+
+	crt := gen.Certificate("test-crt", gen.SetCertificateNamespace("ns1"), gen.SetCertificateSecretName("test-tls"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test-issuer", Kind: "Issuer"}))
+	clientSet := fakekubernetes.NewSimpleClientset(gen.Secret("test-tls", gen.SetSecretNamespace("ns1")))
+	secretGets := 0
+	clientSet.PrependReactor("get", "secrets", func(action coretesting.Action) (bool, runtime.Object, error) {
+		secretGets++
+		if secretGets < 3 {
+			return true, nil, apierrors.NewServiceUnavailable("etcd is unavailable")
+		}
+		return false, nil, nil
+	})
+	cmClient := cmfake.NewSimpleClientset()
+	cmClient.PrependReactor("get", "issuers", func(action coretesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(cmapi.Resource("issuers"), "test-issuer", errors.New("denied"))
+	})
+
+	var errOut bytes.Buffer
+	o := &Options{Verbose: true, IOStreams: genericclioptions.IOStreams{ErrOut: &errOut}}
+	data, err := o.getResourcesForCertificate(context.TODO(), clientSet, typedResourceClient{cmClient: cmClient}, crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, data.SecretError)
+	if assert.NotNil(t, data.Secret) {
+		assert.Equal(t, "test-tls", data.Secret.Name)
+	}
+	assert.True(t, apierrors.IsForbidden(data.IssuerError), "unexpected Issuer error: %v", data.IssuerError)
+	assert.Equal(t, "Lookup attempts for Certificate ns1/test-crt: Issuer 1, Secret 3, CertificateRequests 1\n", errOut.String())
+}
+
+func TestFindSecretContention(t *testing.T) {
+	crtWithSecret := func(name, namespace, secretName string) *cmapi.Certificate {
+		return gen.Certificate(name, gen.SetCertificateNamespace(namespace), gen.SetCertificateSecretName(secretName))
+	}
+	crt := crtWithSecret("test-crt", "ns1", "test-tls")
+	client := typedResourceClient{cmClient: cmfake.NewSimpleClientset(
+		crt,
+		crtWithSecret("other-crt-b", "ns1", "test-tls"),
+		crtWithSecret("other-crt-a", "ns1", "test-tls"),
+		crtWithSecret("unrelated-crt", "ns1", "other-tls"),
+		crtWithSecret("other-namespace-crt", "ns2", "test-tls"),
+	)}
+
+	names, err := findSecretContention(client, context.TODO(), crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"other-crt-a", "other-crt-b"}, names)
+
+	status := (&CertificateStatus{Name: "test-crt", Namespace: "ns1",
+		IssuerStatus: &IssuerStatus{Name: "test-issuer", Kind: "Issuer"},
+		SecretStatus: &SecretStatus{Error: errors.New("error when finding Secret")},
+		CRStatus:     &CRStatus{Name: "test-crt-1"}}).withSecretContention(names)
+	assert.Equal(t, []string{"Secret contention with: other-crt-a, other-crt-b"}, status.warnings())
+	assert.Contains(t, writerToString(status), "warning: Secret contention with: other-crt-a, other-crt-b (SecretContention)")
+}
+
+func TestWriteCRHistory(t *testing.T) {
+	pendingCond := cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionFalse, Reason: "Pending"}
+	issuedCond := cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionTrue, Reason: "Issued"}
+	history := []*CRStatus{
+		{Name: "test-crt-2", Namespace: "ns1", Revision: 2, Age: 5 * time.Minute, Conditions: []cmapi.CertificateRequestCondition{pendingCond}},
+		{Name: "test-crt-1", Namespace: "ns1", Revision: 1, Age: 30 * 24 * time.Hour, Conditions: []cmapi.CertificateRequestCondition{issuedCond}},
+	}
+	table := `CertificateRequest History:
+  Revision  Name        State    Age
+  2         test-crt-2  Pending  5m
+  1         test-crt-1  Issued   30d
+`
+	tests := map[string]struct {
+		status    *CertificateStatus
+		expOutput string
+	}{
+		"no CertificateRequests": {
+			status:    &CertificateStatus{},
+			expOutput: "",
+		},
+		"a line per CertificateRequest": {
+			status:    &CertificateStatus{CRHistory: history},
+			expOutput: table,
+		},
+		"with details": {
+			status: &CertificateStatus{CRHistory: history, CRHistoryDetails: true},
+			expOutput: table + `CertificateRequest:
+  Name: test-crt-2
+  Namespace: ns1
+  Conditions:
+    Ready: False, Reason: Pending, Message: 
+  Events:  <none>
+CertificateRequest:
+  Name: test-crt-1
+  Namespace: ns1
+  Conditions:
+    Ready: True, Reason: Issued, Message: 
+  Events:  <none>
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if _, err := withTabWriter(&buf, test.status.writeCRHistory); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expOutput, buf.String())
+		})
+	}
+}
+
+func TestProbeTLS(t *testing.T) {
+	server := httptest.NewTLSServer(nil)
+	defer server.Close()
+
+	result := probeTLS(server.Listener.Addr().String(), 5*time.Second)
+	if result.Error != nil {
+		t.Fatalf("unexpected error: %v", result.Error)
+	}
+	assert.Equal(t, server.Certificate().Raw, result.Leaf.Raw)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedAddress := listener.Addr().String()
+	listener.Close()
+	result = probeTLS(closedAddress, 5*time.Second)
+	assert.Error(t, result.Error)
+	assert.Nil(t, result.Leaf)
+}
+
+func TestWithProbe(t *testing.T) {
+	servedPEM, _ := generateCertPEM(t, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com", "www.example.com"},
+		NotBefore:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, nil, nil)
+	served, err := pki.DecodeX509CertificateBytes(servedPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(served.Raw)
+	servedFingerprint := formatHexColon(sum[:])
+	matches, differs := true, false
+
+	tests := map[string]struct {
+		result         *ProbeResult
+		secretStatus   *SecretStatus
+		expStatus      *ProbeStatus
+		expWarnings    []string
+		expOutputLines []string
+	}{
+		"no probe": {},
+		"handshake failed": {
+			result:         &ProbeResult{Address: "example.com:443", Error: errors.New("connection refused")},
+			expStatus:      &ProbeStatus{Address: "example.com:443", Error: "connection refused"},
+			expWarnings:    []string{"ProbeFailed"},
+			expOutputLines: []string{"Probe of example.com:443:", "  Error: connection refused"},
+		},
+		"served certificate matches the Secret and the host": {
+			result:       &ProbeResult{Address: "www.example.com:443", Leaf: served},
+			secretStatus: &SecretStatus{FingerprintSHA256: servedFingerprint},
+			expStatus:    &ProbeStatus{Address: "www.example.com:443", FingerprintSHA256: servedFingerprint, MatchesSecret: &matches},
+			expOutputLines: []string{"Probe of www.example.com:443:", "  Served Fingerprint (SHA-256): " + servedFingerprint,
+				"  Matches Secret: yes", "  Hostname: valid"},
+		},
+		"served certificate differs from the Secret": {
+			result:       &ProbeResult{Address: "example.com:8443", Leaf: served},
+			secretStatus: &SecretStatus{FingerprintSHA256: "AA:BB"},
+			expStatus:    &ProbeStatus{Address: "example.com:8443", FingerprintSHA256: servedFingerprint, MatchesSecret: &differs},
+			expWarnings:  []string{"ProbeMismatch"},
+			expOutputLines: []string{"Probe of example.com:8443:", "  Served Fingerprint (SHA-256): " + servedFingerprint,
+				"  Matches Secret: no, the endpoint serves a different certificate than the one in the Secret", "  Hostname: valid"},
+		},
+		"served certificate is not valid for the host and the Secret is unreadable": {
+			result:       &ProbeResult{Address: "other.example.org:443", Leaf: served},
+			secretStatus: &SecretStatus{Error: errors.New("not found")},
+			expStatus: &ProbeStatus{Address: "other.example.org:443", FingerprintSHA256: servedFingerprint,
+				HostnameError: "x509: certificate is valid for example.com, www.example.com, not other.example.org"},
+			expWarnings: []string{"ProbeHostnameInvalid"},
+			expOutputLines: []string{"Probe of other.example.org:443:", "  Served Fingerprint (SHA-256): " + servedFingerprint,
+				"  Matches Secret: unknown, the certificate in the Secret could not be read",
+				"  Hostname: invalid, certificate is valid for example.com, www.example.com, not other.example.org"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := (&CertificateStatus{SecretStatus: test.secretStatus}).withProbe(test.result)
+			assert.Equal(t, test.expStatus, status.ProbeStatus)
+
+			var codes []string
+			for _, warning := range status.Warnings() {
+				if strings.HasPrefix(warning.Code, "Probe") {
+					codes = append(codes, warning.Code)
+				}
+			}
+			assert.Equal(t, test.expWarnings, codes)
+
+			if test.expStatus != nil {
+				assert.Equal(t, strings.Join(test.expOutputLines, "\n")+"\n", status.ProbeStatus.String())
+			}
+		})
+	}
+}
+
+func TestProbeFlag(t *testing.T) {
+	newOptions := func(probe string) *Options {
+		o := NewOptions(genericclioptions.IOStreams{})
+		o.Probe, o.Color, o.FingerprintAlgorithm, o.EventType = probe, colorModeAuto, fingerprintAlgorithmSHA256, eventTypeAll
+		return o
+	}
+
+	for _, probe := range []string{"example.com:443", "[::1]:8443"} {
+		if err := newOptions(probe).Validate([]string{"test-crt"}); err != nil {
+			t.Errorf("unexpected error for --probe %q: %v", probe, err)
+		}
+	}
+
+	assert.EqualError(t, newOptions("example.com").Validate([]string{"test-crt"}),
+		`invalid --probe "example.com", must be host:port: address example.com: missing port in address`)
+	assert.EqualError(t, newOptions(":443").Validate([]string{"test-crt"}),
+		`invalid --probe ":443", must be host:port: address :443 must have both a host and a port`)
+
+	o := newOptions("example.com:443")
+	o.LabelSelector = "app=web"
+	assert.EqualError(t, o.Validate(nil), "cannot specify --probe in conjunction with --from-file, --selector or --all-namespaces")
+}
+
+func TestWriteMetrics(t *testing.T) {
+	notAfter := metav1.NewTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	renewalTime := metav1.NewTime(time.Date(2020, 12, 2, 0, 0, 0, 0, time.UTC))
+	statuses := []*CertificateStatus{
+		{
+			Name: "web", Namespace: "default", NotAfter: &notAfter, RenewalTime: &renewalTime,
+			Conditions:   []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}},
+			IssuerStatus: &IssuerStatus{Name: "letsencrypt", Kind: "ClusterIssuer"},
+		},
+		{
+			Name: "api", Namespace: "prod",
+			Conditions:   []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse}},
+			IssuerStatus: &IssuerStatus{Error: errors.New("not found")},
+		},
+		{
+			Name: "new", Namespace: `weird"ns\`,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeMetrics(&buf, statuses); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `# HELP certmanager_cli_cert_not_after_timestamp_seconds The date after which the certificate expires. Expressed as a Unix Epoch Time.
+# TYPE certmanager_cli_cert_not_after_timestamp_seconds gauge
+certmanager_cli_cert_not_after_timestamp_seconds{name="web",namespace="default",issuer="letsencrypt"} 1609459200
+# HELP certmanager_cli_cert_ready The ready status of the certificate.
+# TYPE certmanager_cli_cert_ready gauge
+certmanager_cli_cert_ready{name="web",namespace="default",issuer="letsencrypt",condition="True"} 1
+certmanager_cli_cert_ready{name="web",namespace="default",issuer="letsencrypt",condition="False"} 0
+certmanager_cli_cert_ready{name="web",namespace="default",issuer="letsencrypt",condition="Unknown"} 0
+certmanager_cli_cert_ready{name="api",namespace="prod",issuer="",condition="True"} 0
+certmanager_cli_cert_ready{name="api",namespace="prod",issuer="",condition="False"} 1
+certmanager_cli_cert_ready{name="api",namespace="prod",issuer="",condition="Unknown"} 0
+certmanager_cli_cert_ready{name="new",namespace="weird\"ns\\",issuer="",condition="True"} 0
+certmanager_cli_cert_ready{name="new",namespace="weird\"ns\\",issuer="",condition="False"} 0
+certmanager_cli_cert_ready{name="new",namespace="weird\"ns\\",issuer="",condition="Unknown"} 1
+# HELP certmanager_cli_cert_renewal_timestamp_seconds The date after which the certificate is renewed. Expressed as a Unix Epoch Time.
+# TYPE certmanager_cli_cert_renewal_timestamp_seconds gauge
+certmanager_cli_cert_renewal_timestamp_seconds{name="web",namespace="default",issuer="letsencrypt"} 1606867200
+`, buf.String())
+}
+
+func TestDNSNameSources(t *testing.T) {
+	tests := map[string]struct {
+		status         *CertificateStatus
+		expRequested   []string
+		expIssued      []string
+		expOutputLines []string
+	}{
+		"only the spec is known": {
+			status: &CertificateStatus{DNSNames: []string{"example.com"},
+				SecretStatus: &SecretStatus{Error: errors.New("not found")}},
+			expOutputLines: []string{"DNS Names (spec):", "- example.com"},
+		},
+		"no DNS Names in the spec": {
+			status:         &CertificateStatus{},
+			expOutputLines: []string{"DNS Names (spec): <none>"},
+		},
+		"all sources agree ignoring order and case": {
+			status: &CertificateStatus{DNSNames: []string{"example.com", "www.example.com"},
+				CRStatus:     &CRStatus{Requested: &RequestedStatus{DNSNames: []string{"www.example.com", "example.com"}}},
+				SecretStatus: &SecretStatus{DNSNames: []string{"Example.com", "www.example.com"}}},
+			expRequested:   []string{"www.example.com", "example.com"},
+			expIssued:      []string{"Example.com", "www.example.com"},
+			expOutputLines: []string{"DNS Names (matching):", "- example.com", "- www.example.com"},
+		},
+		"spec edited after the request": {
+			status: &CertificateStatus{DNSNames: []string{"example.com", "new.example.com"},
+				CRStatus:     &CRStatus{Requested: &RequestedStatus{DNSNames: []string{"example.com"}}},
+				SecretStatus: &SecretStatus{DNSNames: []string{"example.com"}}},
+			expRequested: []string{"example.com"},
+			expIssued:    []string{"example.com"},
+			expOutputLines: []string{"DNS Names (spec):", "- example.com", "- new.example.com",
+				"DNS Names (requested):", "- example.com", "DNS Names (issued):", "- example.com"},
+		},
+		"certificate issued without DNS Names and request not decodable": {
+			status: &CertificateStatus{DNSNames: []string{"example.com"},
+				CRStatus:     &CRStatus{Requested: &RequestedStatus{Error: errors.New("bad request")}},
+				SecretStatus: &SecretStatus{}},
+			expIssued:      []string{},
+			expOutputLines: []string{"DNS Names (spec):", "- example.com", "DNS Names (issued): <none>"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := test.status.withDNSNameSources()
+			assert.Equal(t, test.expRequested, status.RequestedDNSNames)
+			assert.Equal(t, test.expIssued, status.IssuedDNSNames)
+
+			var buf bytes.Buffer
+			status.writeDNSNames(&statusWriter{w: &buf})
+			assert.Equal(t, strings.Join(test.expOutputLines, "\n")+"\n", buf.String())
+		})
+	}
+}
+
+func TestDiagnose(t *testing.T) {
+	matches, mismatches := true, false
+	readyIssuer := &IssuerStatus{Name: "ca-issuer", Kind: "Issuer", Conditions: []cmapi.IssuerCondition{
+		{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue}}}
+	readyCR := &CRStatus{Name: "test-crt-1", Conditions: []cmapi.CertificateRequestCondition{
+		{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionTrue, Reason: "Issued"}}}
+	notAfter := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	validSecret := &SecretStatus{Name: "test-tls", SubjectCommonName: "example.com", KeyMatchesCert: &matches,
+		NotAfter: notAfter, ChainVerified: true, ChainLength: 2}
+
+	tests := map[string]struct {
+		status    *CertificateStatus
+		expOutput string
+	}{
+		"every step passes": {
+			status: &CertificateStatus{IssuerStatus: readyIssuer, CRStatus: readyCR, SecretStatus: validSecret},
+			expOutput: `Diagnosis of Certificate ns1/test-crt:
+  [PASS] Issuer Ready: Issuer "ca-issuer" is Ready
+  [PASS] CertificateRequest Ready: CertificateRequest "test-crt-1" is Ready
+  [PASS] Secret exists and parses: Secret "test-tls" holds a certificate for "example.com"
+  [PASS] Private key matches certificate: the private key in 'tls.key' matches the public key of the certificate
+  [PASS] Certificate within its validity: the certificate is valid until 2021-01-01T00:00:00Z
+  [PASS] Chain verifies: the certificate chains to a trusted root in 2 certificates
+`,
+		},
+		"Issuer not Ready skips every later step": {
+			status: &CertificateStatus{
+				IssuerStatus: &IssuerStatus{Name: "ca-issuer", Kind: "ClusterIssuer", Conditions: []cmapi.IssuerCondition{
+					{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionFalse, Message: "secret not found"}}},
+				CRStatus: readyCR, SecretStatus: validSecret},
+			expOutput: `Diagnosis of Certificate ns1/test-crt:
+  [FAIL] Issuer Ready: ClusterIssuer "ca-issuer" is not Ready: secret not found <- first failure
+  [SKIP] CertificateRequest Ready: not checked, "Issuer Ready" failed
+  [SKIP] Secret exists and parses: not checked, "Issuer Ready" failed
+  [SKIP] Private key matches certificate: not checked, "Issuer Ready" failed
+  [SKIP] Certificate within its validity: not checked, "Issuer Ready" failed
+  [SKIP] Chain verifies: not checked, "Issuer Ready" failed
+`,
+		},
+		"cleaned up CertificateRequest and no ca.crt are skipped, mismatched key fails": {
+			status: &CertificateStatus{IssuerStatus: readyIssuer,
+				CRStatus:     &CRStatus{Error: errNoCertificateRequest},
+				SecretStatus: &SecretStatus{Name: "test-tls", DNSNames: []string{"example.com"}, KeyMatchesCert: &mismatches}},
+			expOutput: `Diagnosis of Certificate ns1/test-crt:
+  [PASS] Issuer Ready: Issuer "ca-issuer" is Ready
+  [SKIP] CertificateRequest Ready: no CertificateRequest found for the current revision
+  [PASS] Secret exists and parses: Secret "test-tls" holds a certificate for example.com
+  [FAIL] Private key matches certificate: the private key in 'tls.key' does not match the public key of the certificate <- first failure
+  [SKIP] Certificate within its validity: not checked, "Private key matches certificate" failed
+  [SKIP] Chain verifies: not checked, "Private key matches certificate" failed
+`,
+		},
+		"pending CertificateRequest": {
+			status: &CertificateStatus{IssuerStatus: readyIssuer,
+				CRStatus:     &CRStatus{Name: "test-crt-2"},
+				SecretStatus: &SecretStatus{Error: errors.New("error when finding Secret \"test-tls\"\n")}},
+			expOutput: `Diagnosis of Certificate ns1/test-crt:
+  [PASS] Issuer Ready: Issuer "ca-issuer" is Ready
+  [FAIL] CertificateRequest Ready: CertificateRequest "test-crt-2" has not been signed yet (approval is not checked, as this API version has no Approved condition) <- first failure
+  [SKIP] Secret exists and parses: not checked, "CertificateRequest Ready" failed
+  [SKIP] Private key matches certificate: not checked, "CertificateRequest Ready" failed
+  [SKIP] Certificate within its validity: not checked, "CertificateRequest Ready" failed
+  [SKIP] Chain verifies: not checked, "CertificateRequest Ready" failed
+`,
+		},
+		"expired certificate with an unverifiable chain": {
+			status: &CertificateStatus{IssuerStatus: readyIssuer, CRStatus: readyCR,
+				SecretStatus: &SecretStatus{Name: "test-tls", SubjectCommonName: "example.com", KeyMatchesCert: &matches,
+					NotAfter: notAfter, Expired: true, ChainError: "x509: certificate signed by unknown authority"}},
+			expOutput: `Diagnosis of Certificate ns1/test-crt:
+  [PASS] Issuer Ready: Issuer "ca-issuer" is Ready
+  [PASS] CertificateRequest Ready: CertificateRequest "test-crt-1" is Ready
+  [PASS] Secret exists and parses: Secret "test-tls" holds a certificate for "example.com"
+  [PASS] Private key matches certificate: the private key in 'tls.key' matches the public key of the certificate
+  [FAIL] Certificate within its validity: the certificate expired at 2021-01-01T00:00:00Z <- first failure
+  [SKIP] Chain verifies: not checked, "Certificate within its validity" failed
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.status.Name, test.status.Namespace = "test-crt", "ns1"
+			var buf bytes.Buffer
+			if _, err := test.status.WriteDiagnosis(&buf); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expOutput, buf.String())
+		})
+	}
+
+	var buf bytes.Buffer
+	status := &CertificateStatus{Name: "test-crt", Namespace: "ns1", IssuerStatus: readyIssuer, CRStatus: &CRStatus{Name: "test-crt-2"}}
+	if _, err := status.WriteDiagnosis(colorWriter{&buf}); err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, buf.String(), "  "+colorGreen+"[PASS]"+colorReset+" Issuer Ready: ")
+	assert.Contains(t, buf.String(), "  "+colorRed+"[FAIL]"+colorReset+" CertificateRequest Ready: ")
+}
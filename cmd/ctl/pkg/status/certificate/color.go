@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"fmt"
+	"io"
+
+	"k8s.io/kubectl/pkg/util/term"
+
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+const (
+	colorModeAuto   = "auto"
+	colorModeAlways = "always"
+	colorModeNever  = "never"
+)
+
+// colorModes are the values of --color. In auto mode, colors are used if the output is a terminal
+// that supports them.
+var colorModes = []string{colorModeAuto, colorModeAlways, colorModeNever}
+
+// isColorMode returns true if mode is one of colorModes
+func isColorMode(mode string) bool {
+	for _, m := range colorModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// colorEnabled returns true if the human readable status written to out is colored
+func (o *Options) colorEnabled(out io.Writer) bool {
+	switch o.Color {
+	case colorModeAlways:
+		return true
+	case colorModeNever:
+		return false
+	default:
+		return term.AllowsColorOutput(out)
+	}
+}
+
+// ANSI escape codes of the colors used in the human readable status
+const (
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+	colorReset  = "\x1b[0m"
+)
+
+// colorWriter marks the writer a status is written to in color. withTabWriter unwraps it,
+// so that the statusWriter of the status and of its related resources writes in color.
+type colorWriter struct {
+	io.Writer
+}
+
+// colorize returns s in color if sw writes in color, else s unchanged
+func (sw *statusWriter) colorize(color, s string) string {
+	if !sw.color || s == "" {
+		return s
+	}
+	return color + s + colorReset
+}
+
+// conditionStatus returns "Type: Status" of a condition, in green if it is a Ready condition that is True
+// and in red if it is a Ready condition that is False
+func (sw *statusWriter) conditionStatus(conditionType string, status cmmeta.ConditionStatus) string {
+	s := fmt.Sprintf("%s: %s", conditionType, status)
+	if conditionType != "Ready" {
+		return s
+	}
+	switch status {
+	case cmmeta.ConditionTrue:
+		return sw.colorize(colorGreen, s)
+	case cmmeta.ConditionFalse:
+		return sw.colorize(colorRed, s)
+	default:
+		return s
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+// diagnosisResult is the outcome of a step of the diagnosis of a Certificate
+type diagnosisResult string
+
+const (
+	diagnosisPass diagnosisResult = "PASS"
+	diagnosisFail diagnosisResult = "FAIL"
+	// The step could not be checked, either because there is nothing to check or because an earlier step failed
+	diagnosisSkip diagnosisResult = "SKIP"
+)
+
+// diagnosisStep is a step of the diagnosis of a Certificate with its outcome
+type diagnosisStep struct {
+	name   string
+	result diagnosisResult
+	detail string
+}
+
+// diagnosisChecks are the steps of the issuance of a Certificate written by --diagnose, in the order they happen.
+// Each check returns its outcome and why, from the status built for the Certificate.
+var diagnosisChecks = []struct {
+	name  string
+	check func(status *CertificateStatus) (diagnosisResult, string)
+}{
+	{"Issuer Ready", diagnoseIssuer},
+	{"CertificateRequest Ready", diagnoseCR},
+	{"Secret exists and parses", diagnoseSecret},
+	{"Private key matches certificate", diagnoseKey},
+	{"Certificate within its validity", diagnoseValidity},
+	{"Chain verifies", diagnoseChain},
+}
+
+// diagnose runs diagnosisChecks against status in order. Once a check fails, the checks after it
+// are not run, as they depend on it, and are skipped.
+func (status *CertificateStatus) diagnose() []diagnosisStep {
+	steps := make([]diagnosisStep, len(diagnosisChecks))
+	failed := ""
+	for i, check := range diagnosisChecks {
+		if failed != "" {
+			steps[i] = diagnosisStep{name: check.name, result: diagnosisSkip, detail: fmt.Sprintf("not checked, %q failed", failed)}
+			continue
+		}
+		result, detail := check.check(status)
+		steps[i] = diagnosisStep{name: check.name, result: result, detail: detail}
+		if result == diagnosisFail {
+			failed = check.name
+		}
+	}
+	return steps
+}
+
+// WriteDiagnosis writes the outcome of each of diagnosisChecks to w as a checklist, with the first failing step highlighted
+func (status *CertificateStatus) WriteDiagnosis(w io.Writer) (int64, error) {
+	return withTabWriter(w, func(sw *statusWriter) {
+		fmt.Fprintf(sw, "Diagnosis of Certificate %s/%s:\n", status.Namespace, status.Name)
+		firstFailure := true
+		for _, step := range status.diagnose() {
+			result := "[" + string(step.result) + "]"
+			marker := ""
+			switch step.result {
+			case diagnosisPass:
+				result = sw.colorize(colorGreen, result)
+			case diagnosisFail:
+				result = sw.colorize(colorRed, result)
+				if firstFailure {
+					marker, firstFailure = " <- first failure", false
+				}
+			}
+			fmt.Fprintf(sw, "  %s %s: %s%s\n", result, step.name, step.detail, marker)
+		}
+	})
+}
+
+func diagnoseIssuer(status *CertificateStatus) (diagnosisResult, string) {
+	issuerStatus := status.IssuerStatus
+	switch {
+	case issuerStatus == nil:
+		return diagnosisFail, "the Issuer could not be looked up"
+	case issuerStatus.Error != nil:
+		return diagnosisFail, strings.TrimSpace(issuerStatus.Error.Error())
+	case issuerStatus.External:
+		return diagnosisSkip, fmt.Sprintf("%s %q is an external issuer, which is not looked up", issuerStatus.Kind, issuerStatus.Name)
+	}
+	switch ready, ok := issuerStatus.IsReady(); {
+	case ok && ready:
+		return diagnosisPass, fmt.Sprintf("%s %q is Ready", issuerStatus.Kind, issuerStatus.Name)
+	case ok:
+		return diagnosisFail, fmt.Sprintf("%s %q is not Ready: %s", issuerStatus.Kind, issuerStatus.Name, issuerReadyMessage(issuerStatus.Conditions))
+	default:
+		return diagnosisFail, fmt.Sprintf("%s %q has no Ready condition yet", issuerStatus.Kind, issuerStatus.Name)
+	}
+}
+
+// issuerReadyMessage returns the message of the Ready condition of conditions
+func issuerReadyMessage(conditions []cmapi.IssuerCondition) string {
+	for _, con := range conditions {
+		if con.Type == cmapi.IssuerConditionReady {
+			return con.Message
+		}
+	}
+	return ""
+}
+
+// diagnoseCR checks the Ready condition of the CertificateRequest. CertificateRequests of this API version
+// have no Approved or Denied condition, so whether the request was approved is not checked.
+func diagnoseCR(status *CertificateStatus) (diagnosisResult, string) {
+	crStatus := status.CRStatus
+	switch {
+	case crStatus == nil:
+		return diagnosisFail, "the CertificateRequest could not be looked up"
+	case errors.Is(crStatus.Error, errNoCertificateRequest):
+		// CertificateRequests of past revisions are cleaned up after issuance, so this is not a failure
+		return diagnosisSkip, "no CertificateRequest found for the current revision"
+	case crStatus.Error != nil:
+		return diagnosisFail, strings.TrimSpace(crStatus.Error.Error())
+	}
+	for _, con := range crStatus.Conditions {
+		if con.Type != cmapi.CertificateRequestConditionReady {
+			continue
+		}
+		switch con.Status {
+		case cmmeta.ConditionTrue:
+			return diagnosisPass, fmt.Sprintf("CertificateRequest %q is Ready", crStatus.Name)
+		case cmmeta.ConditionFalse:
+			return diagnosisFail, fmt.Sprintf("CertificateRequest %q is not Ready, Reason: %s, Message: %s", crStatus.Name, con.Reason, con.Message)
+		}
+	}
+	return diagnosisFail, fmt.Sprintf("CertificateRequest %q has not been signed yet (approval is not checked, as this API version has no Approved condition)", crStatus.Name)
+}
+
+func diagnoseSecret(status *CertificateStatus) (diagnosisResult, string) {
+	secretStatus := status.SecretStatus
+	switch {
+	case secretStatus == nil:
+		return diagnosisFail, fmt.Sprintf("Secret %q could not be looked up", status.SecretName)
+	case secretStatus.Error != nil:
+		return diagnosisFail, strings.TrimSpace(secretStatus.Error.Error())
+	}
+	return diagnosisPass, fmt.Sprintf("Secret %q holds a certificate for %s", secretStatus.Name, diagnosisSubject(secretStatus))
+}
+
+// diagnosisSubject returns the Common Name of the certificate in the Secret, or its DNS Names if it has none
+func diagnosisSubject(secretStatus *SecretStatus) string {
+	if secretStatus.SubjectCommonName != "" {
+		return fmt.Sprintf("%q", secretStatus.SubjectCommonName)
+	}
+	if len(secretStatus.DNSNames) > 0 {
+		return strings.Join(secretStatus.DNSNames, ", ")
+	}
+	return "an empty subject"
+}
+
+func diagnoseKey(status *CertificateStatus) (diagnosisResult, string) {
+	secretStatus := status.SecretStatus
+	switch {
+	case secretStatus.KeyError != nil:
+		return diagnosisFail, secretStatus.KeyError.Error()
+	case secretStatus.KeyMatchesCert == nil:
+		return diagnosisFail, "'tls.key' of the Secret is not set"
+	case !*secretStatus.KeyMatchesCert:
+		return diagnosisFail, "the private key in 'tls.key' does not match the public key of the certificate"
+	}
+	return diagnosisPass, "the private key in 'tls.key' matches the public key of the certificate"
+}
+
+func diagnoseValidity(status *CertificateStatus) (diagnosisResult, string) {
+	secretStatus := status.SecretStatus
+	switch {
+	case secretStatus.NotYetValid:
+		return diagnosisFail, fmt.Sprintf("the certificate is not valid before %s", secretStatus.NotBefore.Format(time.RFC3339))
+	case secretStatus.Expired:
+		return diagnosisFail, fmt.Sprintf("the certificate expired at %s", secretStatus.NotAfter.Format(time.RFC3339))
+	}
+	return diagnosisPass, fmt.Sprintf("the certificate is valid until %s", secretStatus.NotAfter.Format(time.RFC3339))
+}
+
+func diagnoseChain(status *CertificateStatus) (diagnosisResult, string) {
+	secretStatus := status.SecretStatus
+	switch {
+	case secretStatus.ChainError != "":
+		return diagnosisFail, secretStatus.ChainError
+	case secretStatus.ChainVerified:
+		return diagnosisPass, fmt.Sprintf("the certificate chains to a trusted root in %d certificates", secretStatus.ChainLength)
+	}
+	return diagnosisSkip, "'ca.crt' of the Secret is not set, so there is no root to verify against"
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1beta1"
+	dnsutil "github.com/jetstack/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+// DNSLookup is the result of looking up the TXT records of a DNS01 challenge on a single nameserver
+type DNSLookup struct {
+	// Nameserver queried, as host:port
+	Nameserver string `json:"nameserver"`
+	// TXT records observed, nil if none
+	Records []string `json:"records"`
+	// If Error is not nil, the lookup failed and Records is unusable
+	Error error `json:"-"`
+}
+
+// lookupTXTFunc returns the TXT records of fqdn as seen by nameserver
+type lookupTXTFunc func(fqdn, nameserver string) ([]string, error)
+
+// newLookupTXT returns a lookupTXTFunc that queries nameserver recursively for the TXT records of fqdn,
+// each query timing out after timeout. A non-existent fqdn is not an error, it simply has no records.
+// The queries are made here rather than with dnsutil.DNSQuery, whose timeout is a package variable.
+func newLookupTXT(timeout time.Duration) lookupTXTFunc {
+	return func(fqdn, nameserver string) ([]string, error) {
+		return lookupTXT(fqdn, nameserver, timeout)
+	}
+}
+
+func lookupTXT(fqdn, nameserver string, timeout time.Duration) ([]string, error) {
+	query := new(dns.Msg)
+	query.SetQuestion(fqdn, dns.TypeTXT)
+	query.SetEdns0(4096, false)
+
+	msg, _, err := (&dns.Client{Net: "udp", Timeout: timeout}).Exchange(query, nameserver)
+	if msg != nil && msg.Truncated {
+		// As dnsutil.DNSQuery does, retry with TCP when the answer does not fit in a UDP message
+		msg, _, err = (&dns.Client{Net: "tcp", Timeout: timeout}).Exchange(query, nameserver)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if msg.Rcode != dns.RcodeSuccess && msg.Rcode != dns.RcodeNameError {
+		return nil, fmt.Errorf("unexpected response code %s", dns.RcodeToString[msg.Rcode])
+	}
+
+	var records []string
+	for _, rr := range msg.Answer {
+		if txt, ok := rr.(*dns.TXT); ok {
+			records = append(records, strings.Join(txt.Txt, ""))
+		}
+	}
+	return records, nil
+}
+
+// checkDNS01Challenges looks up the TXT record of every DNS01 challenge that is still in progress
+// on each of nameservers. Returns the lookups keyed by the name of the challenge.
+func checkDNS01Challenges(challenges []*cmacme.Challenge, nameservers []string, lookup lookupTXTFunc) map[string][]DNSLookup {
+	lookups := map[string][]DNSLookup{}
+	for _, challenge := range challenges {
+		if challenge.Spec.Type != cmacme.ACMEChallengeTypeDNS01 || !isChallengeInProgress(challenge.Status.State) {
+			continue
+		}
+		fqdn := dns01ChallengeFQDN(challenge.Spec.DNSName)
+		for _, nameserver := range nameservers {
+			records, err := lookup(fqdn, nameserver)
+			lookups[challenge.Name] = append(lookups[challenge.Name], DNSLookup{Nameserver: nameserver, Records: records, Error: err})
+		}
+	}
+	return lookups
+}
+
+// isChallengeInProgress returns false if the challenge has reached a final state
+func isChallengeInProgress(state cmacme.State) bool {
+	switch state {
+	case cmacme.Valid, cmacme.Invalid, cmacme.Expired, cmacme.Errored:
+		return false
+	}
+	return true
+}
+
+// dns01ChallengeFQDN returns the fully qualified name of the TXT record presented for a DNS01 challenge of dnsName
+func dns01ChallengeFQDN(dnsName string) string {
+	return dnsutil.ToFqdn("_acme-challenge." + dnsName)
+}
+
+// withDefaultDNSPort appends port 53 to each of nameservers that has no port
+func withDefaultDNSPort(nameservers []string) []string {
+	var result []string
+	for _, nameserver := range nameservers {
+		if _, _, err := net.SplitHostPort(nameserver); err != nil {
+			nameserver = net.JoinHostPort(nameserver, "53")
+		}
+		result = append(result, nameserver)
+	}
+	return result
+}
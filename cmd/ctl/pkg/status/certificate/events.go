@@ -0,0 +1,71 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// eventTypeAll is the value of --event-type that keeps the events of every type
+const eventTypeAll = "all"
+
+// eventTypes are the values of --event-type
+var eventTypes = []string{eventTypeAll, v1.EventTypeNormal, v1.EventTypeWarning}
+
+// isEventType returns true if eventType is one of eventTypes
+func isEventType(eventType string) bool {
+	for _, t := range eventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// filterEvents returns the events in events of type eventType and how many were left out.
+// events is returned unchanged if eventType is eventTypeAll or empty.
+func filterEvents(events *v1.EventList, eventType string) (*v1.EventList, int) {
+	if events == nil || eventType == "" || eventType == eventTypeAll {
+		return events, 0
+	}
+	filtered := &v1.EventList{TypeMeta: events.TypeMeta, ListMeta: events.ListMeta}
+	for _, event := range events.Items {
+		if event.Type == eventType {
+			filtered.Items = append(filtered.Items, event)
+		}
+	}
+	return filtered, len(events.Items) - len(filtered.Items)
+}
+
+// withEventTypeFilter keeps only the events of type eventType of the Certificate and its related resources,
+// recording how many events of each resource were suppressed
+func (status *CertificateStatus) withEventTypeFilter(eventType string) *CertificateStatus {
+	status.Events, status.SuppressedEvents = filterEvents(status.Events, eventType)
+	if status.IssuerStatus != nil {
+		status.IssuerStatus.Events, status.IssuerStatus.SuppressedEvents = filterEvents(status.IssuerStatus.Events, eventType)
+	}
+	if status.SecretStatus != nil {
+		status.SecretStatus.Events, status.SecretStatus.SuppressedEvents = filterEvents(status.SecretStatus.Events, eventType)
+	}
+	if status.CRStatus != nil {
+		status.CRStatus.Events, status.CRStatus.SuppressedEvents = filterEvents(status.CRStatus.Events, eventType)
+	}
+	for _, crStatus := range status.CRHistory {
+		crStatus.Events, crStatus.SuppressedEvents = filterEvents(crStatus.Events, eventType)
+	}
+	return status
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/yaml"
+
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+// runFromFile prints the status of the certificate in o.FromFile, which is either a Secret manifest,
+// e.g. from 'kubectl get secret -o yaml', or a PEM file, without querying the cluster
+func (o *Options) runFromFile() error {
+	fileData, err := ioutil.ReadFile(o.FromFile)
+	if err != nil {
+		return fmt.Errorf("error when reading file %q: %w", o.FromFile, err)
+	}
+	secret, isPEM, err := secretFromFile(o.FromFile, fileData)
+	if err != nil {
+		return err
+	}
+	if o.ExportCert != "" {
+		return o.exportCert(secret)
+	}
+
+	out, closeOut, err := o.output()
+	if err != nil {
+		return err
+	}
+	defer closeOut()
+
+	if o.DumpChain || o.DumpChainAnnotated {
+		return writeChain(out, secret, o.DumpChainAnnotated)
+	}
+
+	secretStatus := o.statusFromFile(secret, isPEM, o.clock())
+	switch {
+	case o.outputTemplate != nil:
+		err = o.outputTemplate.Execute(out, secretStatus)
+	case o.Output == "json":
+		err = writeJSON(out, secretStatus)
+	case o.Output == "yaml":
+		err = writeYAML(out, secretStatus)
+	case o.colorEnabled(out):
+		_, err = secretStatus.WriteTo(colorWriter{out})
+	default:
+		_, err = secretStatus.WriteTo(out)
+	}
+	return err
+}
+
+// statusFromFile builds the status of the certificate in secret read from o.FromFile.
+// There is no Certificate resource, so only the SecretStatus is built.
+func (o *Options) statusFromFile(secret *corev1.Secret, isPEM bool, clock clock.Clock) *SecretStatus {
+	status := o.withOptions((&CertificateStatus{}).withSecret(secret, nil, nil, clock), secret, clock)
+	if status.SecretStatus.Error == nil {
+		status.SecretStatus.File = o.FromFile
+		status.SecretStatus.PEMFile = isPEM
+	}
+	return status.SecretStatus
+}
+
+// secretFromFile returns the Secret in fileData, read from filename, and whether fileData is a PEM file
+// rather than a Secret manifest. A PEM file is wrapped in a Secret without metadata,
+// so that its certificates go through the same parsing as those of a Secret read from the cluster.
+func secretFromFile(filename string, fileData []byte) (*corev1.Secret, bool, error) {
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal(fileData, &typeMeta); err != nil || typeMeta.Kind == "" {
+		secret, err := secretFromPEM(filename, fileData)
+		return secret, true, err
+	}
+	if typeMeta.Kind != "Secret" {
+		return nil, false, fmt.Errorf("error: file %q holds a %s, not a Secret", filename, typeMeta.Kind)
+	}
+
+	secret := &corev1.Secret{}
+	if err := yaml.Unmarshal(fileData, secret); err != nil {
+		return nil, false, fmt.Errorf("error when parsing Secret in file %q: %w", filename, err)
+	}
+	// The API server merges stringData into data, which a manifest written by hand may not have gone through
+	for key, value := range secret.StringData {
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[key] = []byte(value)
+	}
+	return secret, false, nil
+}
+
+// secretFromPEM returns a Secret with the PEM encoded certificates in fileData in 'tls.crt',
+// in the order they appear, and the first PEM encoded private key in fileData, if any, in 'tls.key'
+func secretFromPEM(filename string, fileData []byte) (*corev1.Secret, error) {
+	var certData, keyData []byte
+	for {
+		var block *pem.Block
+		block, fileData = pem.Decode(fileData)
+		if block == nil {
+			break
+		}
+		switch {
+		case block.Type == "CERTIFICATE":
+			certData = append(certData, pem.EncodeToMemory(block)...)
+		case strings.HasSuffix(block.Type, "PRIVATE KEY") && keyData == nil:
+			keyData = pem.EncodeToMemory(block)
+		}
+	}
+	if len(certData) == 0 {
+		return nil, fmt.Errorf("error: file %q is neither a Secret manifest nor holds a PEM encoded certificate", filename)
+	}
+	if _, err := pki.DecodeX509CertificateChainBytes(certData); err != nil {
+		return nil, fmt.Errorf("error when parsing the certificates in file %q: %s", filename, err)
+	}
+
+	secret := &corev1.Secret{Data: map[string][]byte{"tls.crt": certData}}
+	if keyData != nil {
+		secret.Data["tls.key"] = keyData
+	}
+	return secret, nil
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// The MarshalJSON methods below render the Error of each status as a string under the "error" key,
+// so that errors are not silently dropped, and convert fields that have no stable JSON
+// representation of their own. ToYAML goes through them as well, so both formats have the same fields.
+// A status whose Error makes the rest of its fields unusable is written as its name and error only,
+// see errorStatusJSON.
+
+// ToJSON writes status to w as indented JSON
+func (status *CertificateStatus) ToJSON(w io.Writer) error {
+	return writeJSON(w, status)
+}
+
+// ToYAML writes status to w as YAML, with the same keys as ToJSON
+func (status *CertificateStatus) ToYAML(w io.Writer) error {
+	return writeYAML(w, status)
+}
+
+// writeJSON writes v, a status or a list of statuses, to w as indented JSON
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// writeYAML writes v, a status or a list of statuses, to w as YAML
+func writeYAML(w io.Writer, v interface{}) error {
+	out, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+// certificateStatusAlias has the fields of CertificateStatus without its MarshalJSON method
+type certificateStatusAlias CertificateStatus
+
+// certificateStatusJSON is the JSON representation of a CertificateStatus, with the fields derived from it
+type certificateStatusJSON struct {
+	ConditionsMap map[string]string `json:"conditionsMap,omitempty"`
+	Warnings      []Warning         `json:"warnings,omitempty"`
+	*certificateStatusAlias
+}
+
+func (status *CertificateStatus) toJSON() *certificateStatusJSON {
+	return &certificateStatusJSON{ConditionsMap: status.ConditionsMap(), Warnings: status.Warnings(), certificateStatusAlias: (*certificateStatusAlias)(status)}
+}
+
+func (status *CertificateStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(status.toJSON())
+}
+
+// writeJSONLine writes status to w as a single line of JSON, with the time it was built under the "timestamp" key,
+// so that each refresh in watch mode can be consumed as it is written
+func writeJSONLine(w io.Writer, status *CertificateStatus, timestamp time.Time) error {
+	return json.NewEncoder(w).Encode(&struct {
+		Timestamp time.Time `json:"timestamp"`
+		*certificateStatusJSON
+	}{Timestamp: timestamp, certificateStatusJSON: status.toJSON()})
+}
+
+// errorStatusJSON is the JSON representation of a status whose Error is set, as its other fields are unusable
+// and would otherwise be written with their zero values
+type errorStatusJSON struct {
+	Name  string `json:"name,omitempty"`
+	Error string `json:"error"`
+}
+
+// nonZeroTime returns a pointer to t, or nil if t is zero, so that a time that was not read is left out
+// of the JSON output rather than written as 0001-01-01T00:00:00Z
+func nonZeroTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// errorMessage returns the message of err without the trailing newline used in the text output,
+// or an empty string if err is nil
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return strings.TrimSpace(err.Error())
+}
+
+func (issuerStatus *IssuerStatus) MarshalJSON() ([]byte, error) {
+	if issuerStatus.Error != nil {
+		return json.Marshal(&errorStatusJSON{Name: issuerStatus.Name, Error: errorMessage(issuerStatus.Error)})
+	}
+	type alias IssuerStatus
+	return json.Marshal(&struct {
+		Error         string            `json:"error,omitempty"`
+		ConditionsMap map[string]string `json:"conditionsMap,omitempty"`
+		*alias
+	}{Error: errorMessage(issuerStatus.Error), ConditionsMap: issuerStatus.ConditionsMap(), alias: (*alias)(issuerStatus)})
+}
+
+func (caStatus *IssuerCAStatus) MarshalJSON() ([]byte, error) {
+	if caStatus.Error != nil {
+		// The CA is named by its Secret
+		return json.Marshal(&struct {
+			SecretName string `json:"secretName,omitempty"`
+			Error      string `json:"error"`
+		}{SecretName: caStatus.SecretName, Error: errorMessage(caStatus.Error)})
+	}
+	type alias IssuerCAStatus
+	return json.Marshal(&struct {
+		ExpiresIn *DurationStatus `json:"expiresIn,omitempty"`
+		*alias
+	}{ExpiresIn: newDurationStatus(caStatus.ExpiresIn), alias: (*alias)(caStatus)})
+}
+
+func (keyStatus *NextPrivateKeyStatus) MarshalJSON() ([]byte, error) {
+	type alias NextPrivateKeyStatus
+	var keyAlgorithm string
+	if keyStatus.Error == nil {
+		keyAlgorithm = keyStatus.KeyAlgorithm.String()
+	}
+	return json.Marshal(&struct {
+		Error        string `json:"error,omitempty"`
+		KeyAlgorithm string `json:"keyAlgorithm,omitempty"`
+		*alias
+	}{Error: errorMessage(keyStatus.Error), KeyAlgorithm: keyAlgorithm, alias: (*alias)(keyStatus)})
+}
+
+func (secretStatus *SecretStatus) MarshalJSON() ([]byte, error) {
+	if secretStatus.Error != nil {
+		return json.Marshal(&errorStatusJSON{Name: secretStatus.Name, Error: errorMessage(secretStatus.Error)})
+	}
+	type alias SecretStatus
+	var serialNumber string
+	if secretStatus.SerialNumber != nil {
+		serialNumber = secretStatus.SerialNumber.String()
+	}
+	extKeyUsage, _ := extKeyUsageToString(secretStatus.ExtKeyUsage)
+	// Algorithms that were not read from a certificate are left out rather than written as "0"
+	var publicKeyAlgorithm, signatureAlgorithm string
+	if secretStatus.PublicKeyAlgorithm != x509.UnknownPublicKeyAlgorithm {
+		publicKeyAlgorithm = secretStatus.PublicKeyAlgorithm.String()
+	}
+	if secretStatus.SignatureAlgorithm != x509.UnknownSignatureAlgorithm {
+		signatureAlgorithm = secretStatus.SignatureAlgorithm.String()
+	}
+	return json.Marshal(&struct {
+		KeyError           string          `json:"keyError,omitempty"`
+		ExpiresIn          *DurationStatus `json:"expiresIn,omitempty"`
+		KeyUsage           string          `json:"keyUsage,omitempty"`
+		ExtKeyUsage        string          `json:"extKeyUsage,omitempty"`
+		UnknownExtKeyUsage []string        `json:"unknownExtKeyUsage,omitempty"`
+		PolicyIdentifiers  []string        `json:"policyIdentifiers,omitempty"`
+		PublicKeyAlgorithm string          `json:"publicKeyAlgorithm,omitempty"`
+		SignatureAlgorithm string          `json:"signatureAlgorithm,omitempty"`
+		SubjectKeyId       string          `json:"subjectKeyId,omitempty"`
+		AuthorityKeyId     string          `json:"authorityKeyId,omitempty"`
+		SerialNumber       string          `json:"serialNumber,omitempty"`
+		NotBefore          *time.Time      `json:"notBefore,omitempty"`
+		NotAfter           *time.Time      `json:"notAfter,omitempty"`
+		*alias
+	}{
+		KeyError:           errorMessage(secretStatus.KeyError),
+		ExpiresIn:          newDurationStatus(secretStatus.ExpiresIn),
+		KeyUsage:           keyUsageToString(secretStatus.KeyUsage),
+		ExtKeyUsage:        extKeyUsage,
+		UnknownExtKeyUsage: oidsToStrings(secretStatus.UnknownExtKeyUsage),
+		PolicyIdentifiers:  oidsToStrings(secretStatus.PolicyIdentifiers),
+		PublicKeyAlgorithm: publicKeyAlgorithm,
+		SignatureAlgorithm: signatureAlgorithm,
+		SubjectKeyId:       hex.EncodeToString(secretStatus.SubjectKeyId),
+		AuthorityKeyId:     hex.EncodeToString(secretStatus.AuthorityKeyId),
+		SerialNumber:       serialNumber,
+		NotBefore:          nonZeroTime(secretStatus.NotBefore),
+		NotAfter:           nonZeroTime(secretStatus.NotAfter),
+		alias:              (*alias)(secretStatus),
+	})
+}
+
+func (caCertificate *CACertificateStatus) MarshalJSON() ([]byte, error) {
+	type alias CACertificateStatus
+	var serialNumber string
+	if caCertificate.SerialNumber != nil {
+		serialNumber = caCertificate.SerialNumber.String()
+	}
+	return json.Marshal(&struct {
+		Error        string `json:"error,omitempty"`
+		SerialNumber string `json:"serialNumber,omitempty"`
+		*alias
+	}{Error: errorMessage(caCertificate.Error), SerialNumber: serialNumber, alias: (*alias)(caCertificate)})
+}
+
+func (crStatus *CRStatus) MarshalJSON() ([]byte, error) {
+	if crStatus.Error != nil {
+		return json.Marshal(&errorStatusJSON{Name: crStatus.Name, Error: errorMessage(crStatus.Error)})
+	}
+	type alias CRStatus
+	return json.Marshal((*alias)(crStatus))
+}
+
+func (requestedStatus *RequestedStatus) MarshalJSON() ([]byte, error) {
+	type alias RequestedStatus
+	return json.Marshal(&struct {
+		Error string `json:"error,omitempty"`
+		*alias
+	}{Error: errorMessage(requestedStatus.Error), alias: (*alias)(requestedStatus)})
+}
+
+func (orderStatus *OrderStatus) MarshalJSON() ([]byte, error) {
+	type alias OrderStatus
+	return json.Marshal(&struct {
+		Error string `json:"error,omitempty"`
+		*alias
+	}{Error: errorMessage(orderStatus.Error), alias: (*alias)(orderStatus)})
+}
+
+func (c *ChallengeStatusList) MarshalJSON() ([]byte, error) {
+	type alias ChallengeStatusList
+	return json.Marshal(&struct {
+		Error string `json:"error,omitempty"`
+		*alias
+	}{Error: errorMessage(c.Error), alias: (*alias)(c)})
+}
+
+func (lookup DNSLookup) MarshalJSON() ([]byte, error) {
+	type alias DNSLookup
+	return json.Marshal(&struct {
+		Error string `json:"error,omitempty"`
+		alias
+	}{Error: errorMessage(lookup.Error), alias: alias(lookup)})
+}
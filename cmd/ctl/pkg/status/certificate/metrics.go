@@ -0,0 +1,128 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+// metricsPrefix is the prefix of the name of the metrics written by writeMetrics,
+// so that they do not collide with the metrics of the cert-manager controller
+const metricsPrefix = "certmanager_cli_cert_"
+
+// metricsReadyConditionStatuses are the values of the condition label of the ready metric,
+// as in the certificate_ready_status metric of the cert-manager controller
+var metricsReadyConditionStatuses = []cmmeta.ConditionStatus{cmmeta.ConditionTrue, cmmeta.ConditionFalse, cmmeta.ConditionUnknown}
+
+// metricsSample is a single line of a metric
+type metricsSample struct {
+	labels string
+	value  string
+}
+
+// metricsFamily is a metric with its samples, one or more per Certificate
+type metricsFamily struct {
+	name    string
+	help    string
+	samples func(status *CertificateStatus, labels string) []metricsSample
+}
+
+// metricsFamilies are the metrics written by writeMetrics
+var metricsFamilies = []metricsFamily{
+	{
+		name: "not_after_timestamp_seconds",
+		help: "The date after which the certificate expires. Expressed as a Unix Epoch Time.",
+		samples: func(status *CertificateStatus, labels string) []metricsSample {
+			if status.NotAfter == nil {
+				return nil
+			}
+			return []metricsSample{{labels: labels, value: fmt.Sprint(status.NotAfter.Unix())}}
+		},
+	},
+	{
+		name: "ready",
+		help: "The ready status of the certificate.",
+		samples: func(status *CertificateStatus, labels string) []metricsSample {
+			current := cmmeta.ConditionUnknown
+			switch isReady, ok := status.IsReady(); {
+			case ok && isReady:
+				current = cmmeta.ConditionTrue
+			case ok:
+				current = cmmeta.ConditionFalse
+			}
+			var samples []metricsSample
+			for _, condition := range metricsReadyConditionStatuses {
+				value := "0"
+				if condition == current {
+					value = "1"
+				}
+				samples = append(samples, metricsSample{labels: labels + `,condition="` + string(condition) + `"`, value: value})
+			}
+			return samples
+		},
+	},
+	{
+		name: "renewal_timestamp_seconds",
+		help: "The date after which the certificate is renewed. Expressed as a Unix Epoch Time.",
+		samples: func(status *CertificateStatus, labels string) []metricsSample {
+			if status.RenewalTime == nil {
+				return nil
+			}
+			return []metricsSample{{labels: labels, value: fmt.Sprint(status.RenewalTime.Unix())}}
+		},
+	},
+}
+
+// writeMetrics writes statuses to out in the Prometheus text format, for scripts that push them to a Pushgateway.
+// Each metric has a sample per Certificate, labeled by the name and namespace of the Certificate and the name of its issuer.
+// Timestamps that are not set on a Certificate have no sample.
+func writeMetrics(out io.Writer, statuses []*CertificateStatus) error {
+	sw := &statusWriter{w: out}
+	for _, family := range metricsFamilies {
+		name := metricsPrefix + family.name
+		fmt.Fprintf(sw, "# HELP %s %s\n", name, family.help)
+		fmt.Fprintf(sw, "# TYPE %s gauge\n", name)
+		for _, status := range statuses {
+			for _, sample := range family.samples(status, status.metricsLabels()) {
+				fmt.Fprintf(sw, "%s{%s} %s\n", name, sample.labels, sample.value)
+			}
+		}
+	}
+	return sw.err
+}
+
+// metricsLabels returns the labels identifying the Certificate in the metrics written by writeMetrics.
+// The issuer label is empty if the issuer could not be looked up
+func (status *CertificateStatus) metricsLabels() string {
+	issuer := ""
+	if status.IssuerStatus != nil && status.IssuerStatus.Error == nil {
+		issuer = status.IssuerStatus.Name
+	}
+	return fmt.Sprintf(`name="%s",namespace="%s",issuer="%s"`,
+		escapeMetricsLabel(status.Name), escapeMetricsLabel(status.Namespace), escapeMetricsLabel(issuer))
+}
+
+// metricsLabelEscaper escapes the characters that are not allowed as is in a label value of the Prometheus text format
+var metricsLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeMetricsLabel(value string) string {
+	return metricsLabelEscaper.Replace(value)
+}
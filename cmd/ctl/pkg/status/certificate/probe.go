@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// ProbeResult is the leaf certificate presented by an endpoint in a TLS handshake
+type ProbeResult struct {
+	// Endpoint probed, as host:port
+	Address string
+	// Leaf certificate presented by the endpoint. Nil if Error is not nil
+	Leaf *x509.Certificate
+	// If Error is not nil, the handshake failed
+	Error error
+}
+
+// ProbeStatus compares the certificate served by an endpoint with the certificate in the Secret
+type ProbeStatus struct {
+	// Endpoint probed, as host:port
+	Address string `json:"address"`
+	// Error of the TLS handshake with the endpoint. If set, the other fields are unusable
+	Error string `json:"error,omitempty"`
+	// SHA-256 fingerprint of the leaf certificate presented by the endpoint
+	FingerprintSHA256 string `json:"fingerprintSHA256,omitempty"`
+	// Whether the presented certificate is the certificate in the Secret.
+	// Nil if the certificate in the Secret could not be read
+	MatchesSecret *bool `json:"matchesSecret,omitempty"`
+	// Error of the validation of the host of Address against the SANs of the presented certificate,
+	// empty if the certificate is valid for the host
+	HostnameError string `json:"hostnameError,omitempty"`
+}
+
+// probeTLS makes a TLS handshake with address, a host:port, and returns the leaf certificate it presents.
+// The host is sent as SNI. The certificate is not verified, as the point is to report what is served.
+func probeTLS(address string, timeout time.Duration) ProbeResult {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return ProbeResult{Address: address, Error: err}
+	}
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: timeout}, "tcp", address, &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return ProbeResult{Address: address, Error: err}
+	}
+	defer conn.Close()
+
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return ProbeResult{Address: address, Error: errors.New("the endpoint presented no certificate")}
+	}
+	return ProbeResult{Address: address, Leaf: certs[0]}
+}
+
+// validateProbeAddress returns an error if address is not a host:port
+func validateProbeAddress(address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if host == "" || port == "" {
+		return fmt.Errorf("address %s must have both a host and a port", address)
+	}
+	return nil
+}
+
+// withProbe compares the certificate presented by the endpoint probed in result with the certificate in the Secret,
+// and validates the host of the endpoint against the SANs of the presented certificate
+func (status *CertificateStatus) withProbe(result *ProbeResult) *CertificateStatus {
+	if result == nil {
+		return status
+	}
+	probeStatus := &ProbeStatus{Address: result.Address}
+	status.ProbeStatus = probeStatus
+	if result.Error != nil {
+		probeStatus.Error = result.Error.Error()
+		return status
+	}
+
+	sum := sha256.Sum256(result.Leaf.Raw)
+	probeStatus.FingerprintSHA256 = formatHexColon(sum[:])
+	if status.SecretStatus != nil && status.SecretStatus.Error == nil && status.SecretStatus.FingerprintSHA256 != "" {
+		matches := probeStatus.FingerprintSHA256 == status.SecretStatus.FingerprintSHA256
+		probeStatus.MatchesSecret = &matches
+	}
+	host, _, _ := net.SplitHostPort(result.Address)
+	if err := result.Leaf.VerifyHostname(host); err != nil {
+		probeStatus.HostnameError = err.Error()
+	}
+	return status
+}
+
+// String returns the comparison of the served certificate with the certificate in the Secret as a string to be printed as output
+func (probeStatus *ProbeStatus) String() string {
+	output := fmt.Sprintf("Probe of %s:\n", probeStatus.Address)
+	if probeStatus.Error != "" {
+		return output + fmt.Sprintf("  Error: %s\n", probeStatus.Error)
+	}
+	output += fmt.Sprintf("  Served Fingerprint (SHA-256): %s\n", probeStatus.FingerprintSHA256)
+	switch {
+	case probeStatus.MatchesSecret == nil:
+		output += "  Matches Secret: unknown, the certificate in the Secret could not be read\n"
+	case *probeStatus.MatchesSecret:
+		output += "  Matches Secret: yes\n"
+	default:
+		output += "  Matches Secret: no, the endpoint serves a different certificate than the one in the Secret\n"
+	}
+	if probeStatus.HostnameError != "" {
+		output += fmt.Sprintf("  Hostname: invalid, %s\n", strings.TrimPrefix(probeStatus.HostnameError, "x509: "))
+	} else {
+		output += "  Hostname: valid\n"
+	}
+	return output
+}
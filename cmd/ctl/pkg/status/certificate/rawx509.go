@@ -0,0 +1,214 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+// rawHexBytesPerLine is the number of bytes on each line of a hex dump in the raw certificate
+const rawHexBytesPerLine = 16
+
+// rawExtensionNames are the names of the extensions printed by --raw-x509, keyed by OID.
+// Extensions missing from this table are printed by OID.
+var rawExtensionNames = map[string]string{
+	"2.5.29.14":               "X509v3 Subject Key Identifier",
+	"2.5.29.15":               "X509v3 Key Usage",
+	"2.5.29.17":               "X509v3 Subject Alternative Name",
+	"2.5.29.19":               "X509v3 Basic Constraints",
+	"2.5.29.30":               "X509v3 Name Constraints",
+	"2.5.29.31":               "X509v3 CRL Distribution Points",
+	"2.5.29.32":               "X509v3 Certificate Policies",
+	"2.5.29.35":               "X509v3 Authority Key Identifier",
+	"2.5.29.37":               "X509v3 Extended Key Usage",
+	"1.3.6.1.5.5.7.1.1":       "Authority Information Access",
+	"1.3.6.1.4.1.11129.2.4.2": "CT Precertificate SCTs",
+	"1.3.6.1.4.1.11129.2.4.3": "CT Precertificate Poison",
+}
+
+// writeRawX509 writes every field and extension of cert in a layout like that of 'openssl x509 -text',
+// indented under the Secret. Extensions that crypto/x509 does not decode are printed as a hex dump of their value
+func writeRawX509(w io.Writer, cert *x509.Certificate) {
+	fmt.Fprint(w, "  Raw Certificate:\n")
+	fmt.Fprintf(w, "    Version: %d (0x%x)\n", cert.Version, cert.Version-1)
+	fmt.Fprintf(w, "    Serial Number: %s\n", SerialNumberToString(cert.SerialNumber))
+	fmt.Fprintf(w, "    Signature Algorithm: %s\n", cert.SignatureAlgorithm)
+	fmt.Fprintf(w, "    Issuer: %s\n", cert.Issuer)
+	fmt.Fprint(w, "    Validity:\n")
+	fmt.Fprintf(w, "      Not Before: %s\n", cert.NotBefore.Format(time.RFC3339))
+	fmt.Fprintf(w, "      Not After: %s\n", cert.NotAfter.Format(time.RFC3339))
+	fmt.Fprintf(w, "    Subject: %s\n", cert.Subject)
+	fmt.Fprint(w, "    Subject Public Key Info:\n")
+	fmt.Fprintf(w, "      Public Key Algorithm: %s\n", cert.PublicKeyAlgorithm)
+	writeRawPublicKey(w, cert.PublicKey)
+	if len(cert.Extensions) > 0 {
+		fmt.Fprint(w, "    X509v3 Extensions:\n")
+		for _, ext := range cert.Extensions {
+			name, ok := rawExtensionNames[ext.Id.String()]
+			if !ok {
+				name = ext.Id.String()
+			}
+			if ext.Critical {
+				name += ", critical"
+			}
+			fmt.Fprintf(w, "      %s:\n", name)
+			for _, line := range rawExtensionValue(cert, ext) {
+				fmt.Fprintf(w, "        %s\n", line)
+			}
+		}
+	}
+	fmt.Fprint(w, "    Signature Value:\n")
+	writeRawHex(w, "      ", cert.Signature)
+}
+
+// writeRawPublicKey writes the parameters of publicKey, or a note if its type is not known
+func writeRawPublicKey(w io.Writer, publicKey crypto.PublicKey) {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		fmt.Fprintf(w, "      RSA Public Key: (%d bit)\n", key.N.BitLen())
+		fmt.Fprint(w, "      Modulus:\n")
+		writeRawHex(w, "        ", key.N.Bytes())
+		fmt.Fprintf(w, "      Exponent: %d (0x%x)\n", key.E, key.E)
+	case *ecdsa.PublicKey:
+		fmt.Fprintf(w, "      Public Key: (%d bit)\n", key.Curve.Params().BitSize)
+		fmt.Fprint(w, "      pub:\n")
+		writeRawHex(w, "        ", elliptic.Marshal(key.Curve, key.X, key.Y))
+		fmt.Fprintf(w, "      Curve: %s\n", key.Curve.Params().Name)
+	case ed25519.PublicKey:
+		fmt.Fprint(w, "      pub:\n")
+		writeRawHex(w, "        ", key)
+	default:
+		fmt.Fprint(w, "      Unable to print the public key of this type\n")
+	}
+}
+
+// rawExtensionValue returns the lines describing the value of ext, decoded from the fields crypto/x509 parsed it into.
+// Extensions that crypto/x509 only keeps in Extensions are returned as a hex dump of their value
+func rawExtensionValue(cert *x509.Certificate, ext pkix.Extension) []string {
+	switch ext.Id.String() {
+	case "2.5.29.14":
+		return []string{formatHexColon(cert.SubjectKeyId)}
+	case "2.5.29.15":
+		return []string{keyUsageToString(cert.KeyUsage)}
+	case "2.5.29.17":
+		var names []string
+		for _, dnsName := range cert.DNSNames {
+			names = append(names, "DNS:"+dnsName)
+		}
+		for _, ip := range pki.IPAddressesToString(cert.IPAddresses) {
+			names = append(names, "IP Address:"+ip)
+		}
+		for _, uri := range pki.URLsToString(cert.URIs) {
+			names = append(names, "URI:"+uri)
+		}
+		for _, email := range cert.EmailAddresses {
+			names = append(names, "email:"+email)
+		}
+		return []string{strings.Join(names, ", ")}
+	case "2.5.29.19":
+		if !cert.IsCA {
+			return []string{"CA:FALSE"}
+		}
+		if pathLen := maxPathLen(cert); pathLen != nil {
+			return []string{fmt.Sprintf("CA:TRUE, pathlen:%d", *pathLen)}
+		}
+		return []string{"CA:TRUE"}
+	case "2.5.29.30":
+		var lines []string
+		for _, constraint := range []struct {
+			name  string
+			names []string
+		}{
+			{"Permitted DNS Domains", cert.PermittedDNSDomains},
+			{"Excluded DNS Domains", cert.ExcludedDNSDomains},
+			{"Permitted IP Ranges", ipNetsToStrings(cert.PermittedIPRanges)},
+			{"Excluded IP Ranges", ipNetsToStrings(cert.ExcludedIPRanges)},
+			{"Permitted Email Addresses", cert.PermittedEmailAddresses},
+			{"Excluded Email Addresses", cert.ExcludedEmailAddresses},
+			{"Permitted URI Domains", cert.PermittedURIDomains},
+			{"Excluded URI Domains", cert.ExcludedURIDomains},
+		} {
+			if len(constraint.names) > 0 {
+				lines = append(lines, fmt.Sprintf("%s: %s", constraint.name, strings.Join(constraint.names, ", ")))
+			}
+		}
+		return lines
+	case "2.5.29.31":
+		var lines []string
+		for _, url := range cert.CRLDistributionPoints {
+			lines = append(lines, "URI:"+url)
+		}
+		return lines
+	case "2.5.29.32":
+		var lines []string
+		for _, policy := range oidsToStrings(cert.PolicyIdentifiers) {
+			lines = append(lines, "Policy: "+policy)
+		}
+		return lines
+	case "2.5.29.35":
+		return []string{"keyid:" + formatHexColon(cert.AuthorityKeyId)}
+	case "2.5.29.37":
+		usages, _ := extKeyUsageToString(cert.ExtKeyUsage)
+		lines := []string{usages}
+		for _, oid := range oidsToStrings(cert.UnknownExtKeyUsage) {
+			lines = append(lines, "OID: "+oid)
+		}
+		return lines
+	case "1.3.6.1.5.5.7.1.1":
+		var lines []string
+		for _, url := range cert.OCSPServer {
+			lines = append(lines, "OCSP - URI:"+url)
+		}
+		for _, url := range cert.IssuingCertificateURL {
+			lines = append(lines, "CA Issuers - URI:"+url)
+		}
+		return lines
+	case oidExtensionCTPoison.String():
+		return []string{"NULL"}
+	default:
+		return rawHexLines(ext.Value)
+	}
+}
+
+// writeRawHex writes b as colon separated hex, rawHexBytesPerLine bytes per line, each line prefixed with indent
+func writeRawHex(w io.Writer, indent string, b []byte) {
+	for _, line := range rawHexLines(b) {
+		fmt.Fprintf(w, "%s%s\n", indent, line)
+	}
+}
+
+// rawHexLines returns b as colon separated hex, split into lines of rawHexBytesPerLine bytes
+func rawHexLines(b []byte) []string {
+	var lines []string
+	for len(b) > rawHexBytesPerLine {
+		lines = append(lines, formatHexColon(b[:rawHexBytesPerLine])+":")
+		b = b[rawHexBytesPerLine:]
+	}
+	return append(lines, formatHexColon(b))
+}
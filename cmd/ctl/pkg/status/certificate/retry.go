@@ -0,0 +1,84 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+)
+
+// lookupBackoff bounds the retries of a lookup that failed with a transient error
+var lookupBackoff = retry.DefaultBackoff
+
+// retryLookup calls lookup until it succeeds, fails with an error that is not transient or lookupBackoff
+// is exhausted, and returns how many times it was called and the error of the last call.
+// It stops retrying once ctx is done, so the retries do not outlast --lookup-timeout.
+func retryLookup(ctx context.Context, lookup func() error) (int, error) {
+	attempts := 0
+	err := retry.OnError(lookupBackoff, func(err error) bool {
+		return ctx.Err() == nil && isTransientError(err)
+	}, func() error {
+		attempts++
+		return lookup()
+	})
+	return attempts, err
+}
+
+// isTransientError returns true if err may not happen again if the request is retried: server errors,
+// conflicts, rate limiting and network timeouts. Errors such as NotFound and Forbidden are not transient.
+func isTransientError(err error) bool {
+	var statusErr apierrors.APIStatus
+	if errors.As(err, &statusErr) {
+		code := statusErr.Status().Code
+		return code >= http.StatusInternalServerError || code == http.StatusConflict || code == http.StatusTooManyRequests
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// lookupAttempt is the number of attempts the lookup of a resource of a Certificate took
+type lookupAttempt struct {
+	resource string
+	attempts int
+}
+
+// lookupAttempts are the lookups for a Certificate, printed with --verbose
+type lookupAttempts []lookupAttempt
+
+// add records the attempts of the lookup of resource, unless the lookup did not happen
+func (l *lookupAttempts) add(resource string, attempts int) {
+	if attempts > 0 {
+		*l = append(*l, lookupAttempt{resource: resource, attempts: attempts})
+	}
+}
+
+// writeTo writes the attempts as a single line about the Certificate namespace/name
+func (l lookupAttempts) writeTo(w io.Writer, namespace, name string) {
+	parts := make([]string, len(l))
+	for i, lookup := range l {
+		parts[i] = fmt.Sprintf("%s %d", lookup.resource, lookup.attempts)
+	}
+	fmt.Fprintf(w, "Lookup attempts for Certificate %s/%s: %s\n", namespace, name, strings.Join(parts, ", "))
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"crypto/x509"
+)
+
+const (
+	signatureStrengthUnknown = "unknown"
+	// signatureStrengthWeak is the tier of deprecated algorithms, which are always warned about
+	signatureStrengthWeak = "weak"
+)
+
+// signatureStrengthTiers lists the signature strength tiers accepted by
+// --min-signature-strength, ordered from weakest to strongest.
+var signatureStrengthTiers = []string{signatureStrengthWeak, "sha256", "sha384", "sha512"}
+
+// signatureAlgorithmTiers maps each signature algorithm to its strength tier.
+// Algorithms missing from this table have an unknown strength and never meet a policy.
+// To flag another algorithm as deprecated, add it with the weak tier.
+var signatureAlgorithmTiers = map[x509.SignatureAlgorithm]string{
+	x509.MD2WithRSA:    signatureStrengthWeak,
+	x509.MD5WithRSA:    signatureStrengthWeak,
+	x509.SHA1WithRSA:   signatureStrengthWeak,
+	x509.DSAWithSHA1:   signatureStrengthWeak,
+	x509.ECDSAWithSHA1: signatureStrengthWeak,
+
+	x509.SHA256WithRSA:    "sha256",
+	x509.SHA256WithRSAPSS: "sha256",
+	x509.DSAWithSHA256:    "sha256",
+	x509.ECDSAWithSHA256:  "sha256",
+
+	x509.SHA384WithRSA:    "sha384",
+	x509.SHA384WithRSAPSS: "sha384",
+	x509.ECDSAWithSHA384:  "sha384",
+
+	x509.SHA512WithRSA:    "sha512",
+	x509.SHA512WithRSAPSS: "sha512",
+	x509.ECDSAWithSHA512:  "sha512",
+	x509.PureEd25519:      "sha512",
+}
+
+// signatureStrength returns the strength tier of algo, or "unknown" if it is not in the tier table
+func signatureStrength(algo x509.SignatureAlgorithm) string {
+	if tier, ok := signatureAlgorithmTiers[algo]; ok {
+		return tier
+	}
+	return signatureStrengthUnknown
+}
+
+// isWeakSignatureAlgorithm returns true if algo is deprecated as weak, such as the MD5 and SHA-1 based algorithms
+func isWeakSignatureAlgorithm(algo x509.SignatureAlgorithm) bool {
+	return signatureStrength(algo) == signatureStrengthWeak
+}
+
+// signatureStrengthRank returns the position of tier in signatureStrengthTiers, or -1 if it is not a valid tier
+func signatureStrengthRank(tier string) int {
+	for i, t := range signatureStrengthTiers {
+		if t == tier {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"text/template"
+)
+
+const (
+	goTemplateOutputPrefix     = "go-template="
+	goTemplateFileOutputPrefix = "go-template-file="
+)
+
+// templateFuncs are the functions available to --output go-template, so that templates can format
+// fields the same way as the human readable output
+var templateFuncs = template.FuncMap{
+	"hex":      hex.EncodeToString,
+	"keyUsage": keyUsageToString,
+	"extKeyUsage": func(extUsages []x509.ExtKeyUsage) string {
+		// Unknown Extended Usages are already named in the string, so they need not fail the template
+		extUsageString, _ := extKeyUsageToString(extUsages)
+		return extUsageString
+	},
+}
+
+// isTemplateOutput returns true if output is one of the go-template output formats
+func isTemplateOutput(output string) bool {
+	return strings.HasPrefix(output, goTemplateOutputPrefix) || strings.HasPrefix(output, goTemplateFileOutputPrefix)
+}
+
+// parseOutputTemplate parses the template given by an --output of the form go-template=TEMPLATE
+// or go-template-file=FILENAME, which is executed against the CertificateStatus
+func parseOutputTemplate(output string) (*template.Template, error) {
+	text := strings.TrimPrefix(output, goTemplateOutputPrefix)
+	if strings.HasPrefix(output, goTemplateFileOutputPrefix) {
+		filename := strings.TrimPrefix(output, goTemplateFileOutputPrefix)
+		data, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("error when reading template file %q: %w", filename, err)
+		}
+		text = string(data)
+	}
+	if text == "" {
+		return nil, fmt.Errorf("invalid --output %q, the template must not be empty", output)
+	}
+
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("error when parsing template of --output: %w", err)
+	}
+	return tmpl, nil
+}
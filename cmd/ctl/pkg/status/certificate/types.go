@@ -18,153 +18,618 @@ package certificate
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
 	"crypto/x509"
-	"encoding/hex"
+	"encoding/asn1"
+	"errors"
 	"fmt"
+	"io"
 	"math/big"
+	"net"
+	"sort"
+	"strconv"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/kubectl/pkg/describe"
+	"k8s.io/utils/clock"
+	"software.sslmate.com/src/go-pkcs12"
 
 	"github.com/jetstack/cert-manager/cmd/ctl/pkg/status/util"
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1beta1"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/pkg/util/predicate"
 )
 
 type CertificateStatus struct {
 	// Name of the Certificate resource
-	Name string
+	Name string `json:"name"`
 	// Namespace of the Certificate resource
-	Namespace string
+	Namespace string `json:"namespace"`
 	// Creation Time of Certificate resource
-	CreationTime metav1.Time
+	CreationTime metav1.Time `json:"creationTime"`
+	// Revision of the x509 certificate issued for Certificate resource. Nil if it has not been issued yet
+	Revision *int `json:"revision,omitempty"`
+	// Whether Certificate resource has an Issuing condition with status True, i.e. is being (re)issued
+	Issuing bool `json:"issuing,omitempty"`
 	// Conditions of Certificate resource
-	Conditions []cmapi.CertificateCondition
-	// DNS Names of Certificate resource
-	DNSNames []string
+	Conditions []cmapi.CertificateCondition `json:"conditions,omitempty"`
+	// DNS Names of the spec of Certificate resource
+	DNSNames []string `json:"dnsNames,omitempty"`
+	// DNS Names requested by the certificate signing request of the current CertificateRequest resource.
+	// Nil if there is no CertificateRequest or its request could not be decoded
+	RequestedDNSNames []string `json:"requestedDNSNames,omitempty"`
+	// DNS Names of the x509 certificate in the Secret. Nil if the Secret could not be read
+	IssuedDNSNames []string `json:"issuedDNSNames,omitempty"`
+	// Whether the DNS Names of Certificate resource are the DNS Names of the x509 certificate in the Secret,
+	// ignoring order and case. Nil if the Secret could not be read
+	SpecMatchesIssued *bool `json:"specMatchesIssued,omitempty"`
+	// DNS Names of Certificate resource missing from the x509 certificate in the Secret
+	MissingDNSNames []string `json:"missingDNSNames,omitempty"`
+	// DNS Names of the x509 certificate in the Secret not in Certificate resource
+	ExtraDNSNames []string `json:"extraDNSNames,omitempty"`
+	// Whether Certificate resource requests a CA certificate
+	IsCA bool `json:"isCA,omitempty"`
+	// Name of the Secret that Certificate resource stores its certificate in
+	SecretName string `json:"secretName,omitempty"`
+	// Whether Certificate resource requests a CA certificate but the x509 certificate in the Secret
+	// is not marked as one in its Basic Constraints
+	IssuedNotCA bool `json:"issuedNotCA,omitempty"`
+	// Names of the other Certificates in the namespace of Certificate resource with the same Secret Name,
+	// which fight over the Secret. Only looked up with --check-secret-contention
+	SecretContention []string `json:"secretContention,omitempty"`
 	// Events of Certificate resource
-	Events *v1.EventList
+	Events *v1.EventList `json:"events,omitempty"`
+	// If true, the events were not looked up and are not printed
+	HideEvents bool `json:"-"`
+	// Number of events left out of Events by --event-type
+	SuppressedEvents int `json:"suppressedEvents,omitempty"`
+	// Time the status was built at, from which the age of each event is computed.
+	// Must be set, as the events would otherwise be aged from year 1. The constructors set it from their clock
+	ObservedAt time.Time `json:"-"`
 	// Not Before of Certificate resource
-	NotBefore *metav1.Time
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
 	// Not After of Certificate resource
-	NotAfter *metav1.Time
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
 	// Renewal Time of Certificate resource
-	RenewalTime *metav1.Time
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+	// Durations computed from the timestamps of Certificate resource at the time the status was built
+	Validity *ValidityStatus `json:"validity,omitempty"`
+	// Whether the Certificate should already be renewing at the time the status was built, one of renewalStates.
+	// Empty if neither the Renewal Time of Certificate resource nor the x509 certificate in the Secret is known
+	RenewalState string `json:"renewalState,omitempty"`
+	// spec.duration of Certificate resource, or the default duration of cert-manager if it is not set
+	RequestedDuration *DurationStatus `json:"requestedDuration,omitempty"`
+	// Time between Not Before and Not After of the x509 certificate in the Secret. Nil if the Secret could not be parsed
+	ActualDuration *DurationStatus `json:"actualDuration,omitempty"`
+	// Whether the issuer shortened the duration, i.e. ActualDuration is less than RequestedDuration
+	DurationShortened bool `json:"durationShortened,omitempty"`
 
-	IssuerStatus *IssuerStatus
+	IssuerStatus *IssuerStatus `json:"issuer,omitempty"`
 
-	SecretStatus *SecretStatus
+	SecretStatus *SecretStatus `json:"secret,omitempty"`
 
-	CRStatus *CRStatus
+	// Comparison of the certificate served by the endpoint given by --probe with the certificate in the Secret,
+	// nil if no endpoint was probed
+	ProbeStatus *ProbeStatus `json:"probe,omitempty"`
 
-	OrderStatus *OrderStatus
+	// Status of the temporary Secret holding the private key of the next revision while the Certificate
+	// is being reissued, nil if status.nextPrivateKeySecretName of Certificate resource is not set
+	NextPrivateKeyStatus *NextPrivateKeyStatus `json:"nextPrivateKey,omitempty"`
 
-	ChallengeStatusList *ChallengeStatusList
+	CRStatus *CRStatus `json:"certificateRequest,omitempty"`
+
+	// CertificateRequests owned by the Certificate, from the newest revision to the oldest
+	CRHistory []*CRStatus `json:"certificateRequestHistory,omitempty"`
+	// If true, the details and events of each CertificateRequest in CRHistory are printed,
+	// instead of a single line per CertificateRequest
+	CRHistoryDetails bool `json:"-"`
+
+	OrderStatus *OrderStatus `json:"order,omitempty"`
+
+	ChallengeStatusList *ChallengeStatusList `json:"challenges,omitempty"`
+}
+
+type ValidityStatus struct {
+	// Time until the Certificate expires, negative if already expired. Nil if Not After is not set
+	ExpiresIn *DurationStatus `json:"expiresIn,omitempty"`
+	// Time between Not Before and Not After. Nil if either is not set
+	Lifetime *DurationStatus `json:"lifetime,omitempty"`
+	// Time until cert-manager renews the Certificate, negative if overdue. Nil if Renewal Time is not set
+	RenewalIn *DurationStatus `json:"renewalIn,omitempty"`
+	// True if Renewal Time is after Not After, so cert-manager would only renew the Certificate once it has expired
+	RenewalAfterExpiry bool `json:"renewalAfterExpiry,omitempty"`
+	// True if Renewal Time has already passed
+	RenewalOverdue bool `json:"renewalOverdue,omitempty"`
+}
+
+// DurationStatus is a duration in the representations preferred by machine consumers
+type DurationStatus struct {
+	// Length of the duration in seconds
+	Seconds int64 `json:"seconds"`
+	// ISO 8601 representation of the duration, e.g. P12DT3H
+	ISO8601 string `json:"iso8601"`
 }
 
 type IssuerStatus struct {
 	// If Error is not nil, there was a problem getting the status of the Issuer/ClusterIssuer resource,
 	// so the rest of the fields is unusable
-	Error error
+	Error error `json:"-"`
 	// Name of the Issuer/ClusterIssuer resource
-	Name string
-	// Kind of the resource, can be Issuer or ClusterIssuer
-	Kind string
+	Name string `json:"name"`
+	// Kind of the resource, can be Issuer or ClusterIssuer, or any kind of an external issuer
+	Kind string `json:"kind"`
+	// API group of the resource as referenced by the Certificate
+	Group string `json:"group,omitempty"`
+	// Whether the resource is an external issuer, i.e. not of the API group of cert-manager.
+	// External issuers are not looked up, so only Name, Kind and Group are set
+	External bool `json:"external,omitempty"`
+	// Type of the Issuer/ClusterIssuer, from the config set in its spec, e.g. ACME or CA.
+	// Empty if its spec sets none of the types known to this command
+	Type string `json:"type,omitempty"`
+	// Where the Issuer/ClusterIssuer gets certificates from, e.g. the ACME server or the Venafi zone.
+	// Empty for types without one, such as SelfSigned
+	Endpoint string `json:"endpoint,omitempty"`
 	// Conditions of Issuer/ClusterIssuer resource
-	Conditions []cmapi.IssuerCondition
+	Conditions []cmapi.IssuerCondition `json:"conditions,omitempty"`
+	// Time the status was built at, from which the age of each Condition and event is computed.
+	// Must be set, as the events would otherwise be aged from year 1. The constructors set it from their clock.
+	// If zero, only the Last Transition Time of the Conditions is printed
+	ObservedAt time.Time `json:"-"`
+	// Namespaces of namespaced Issuers with the same name as the ClusterIssuer, if looked up
+	SameNameIssuerNamespaces []string `json:"sameNameIssuerNamespaces,omitempty"`
+	// Status of the CA certificate of a CA Issuer/ClusterIssuer, nil if not a CA Issuer/ClusterIssuer
+	CAStatus *IssuerCAStatus `json:"ca,omitempty"`
 	// Events of Issuer/ClusterIssuer resource
-	Events *v1.EventList
+	Events *v1.EventList `json:"events,omitempty"`
+	// If true, the events were not looked up and are not printed
+	HideEvents bool `json:"-"`
+	// Number of events left out of Events by --event-type
+	SuppressedEvents int `json:"suppressedEvents,omitempty"`
+}
+
+type IssuerCAStatus struct {
+	// If Error is not nil, there was a problem getting the CA certificate of the Issuer/ClusterIssuer,
+	// so the rest of the fields is unusable
+	Error error `json:"-"`
+	// Name of the Secret holding the CA certificate
+	SecretName string `json:"secretName"`
+	// Not After of the CA certificate
+	NotAfter time.Time `json:"notAfter"`
+	// Time until the CA certificate expires, negative if already expired
+	ExpiresIn time.Duration `json:"-"`
+}
+
+type NextPrivateKeyStatus struct {
+	// If Error is not nil, there was a problem getting or parsing the next private key,
+	// so the rest of the fields is unusable
+	Error error `json:"-"`
+	// Name of the Secret holding the next private key
+	SecretName string `json:"secretName"`
+	// Algorithm of the next private key
+	KeyAlgorithm x509.PublicKeyAlgorithm `json:"-"`
+	// Size in bits of the next private key, the modulus for RSA keys
+	KeyBits int `json:"keyBits,omitempty"`
+	// Curve of the next private key, empty for RSA and Ed25519 keys
+	KeyCurve string `json:"keyCurve,omitempty"`
 }
 
 type SecretStatus struct {
 	// If Error is not nil, there was a problem getting the status of the Secret resource,
 	// so the rest of the fields is unusable
-	Error error
+	Error error `json:"-"`
+	// Whether the Secret resource does not exist, which is expected until the first certificate has been issued
+	NotCreated bool `json:"notCreated,omitempty"`
 	// Name of the Secret resource
-	Name string
+	Name string `json:"name"`
 	// Issuer Countries of the x509 certificate in the Secret
-	IssuerCountry []string
+	IssuerCountry []string `json:"issuerCountry,omitempty"`
 	// Issuer Organisations of the x509 certificate in the Secret
-	IssuerOrganisation []string
+	IssuerOrganisation []string `json:"issuerOrganisation,omitempty"`
 	// Issuer Common Name of the x509 certificate in the Secret
-	IssuerCommonName string
+	IssuerCommonName string `json:"issuerCommonName,omitempty"`
+	// Subject Countries of the x509 certificate in the Secret
+	SubjectCountry []string `json:"subjectCountry,omitempty"`
+	// Subject Organisations of the x509 certificate in the Secret
+	SubjectOrganisation []string `json:"subjectOrganisation,omitempty"`
+	// Subject Organisational Units of the x509 certificate in the Secret
+	SubjectOrganizationalUnit []string `json:"subjectOrganizationalUnit,omitempty"`
+	// Subject Common Name of the x509 certificate in the Secret
+	SubjectCommonName string `json:"subjectCommonName,omitempty"`
+	// DNS Names in the Subject Alternative Names of the x509 certificate in the Secret
+	DNSNames []string `json:"dnsNames,omitempty"`
+	// IP Addresses in the Subject Alternative Names of the x509 certificate in the Secret
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+	// URIs in the Subject Alternative Names of the x509 certificate in the Secret
+	URIs []string `json:"uris,omitempty"`
+	// Email Addresses in the Subject Alternative Names of the x509 certificate in the Secret
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
 	// Key Usage of the x509 certificate in the Secret
-	KeyUsage x509.KeyUsage
+	KeyUsage x509.KeyUsage `json:"-"`
 	// Extended Key Usage of the x509 certificate in the Secret
-	ExtKeyUsage []x509.ExtKeyUsage
+	ExtKeyUsage []x509.ExtKeyUsage `json:"-"`
+	// Extended Key Usages of the x509 certificate in the Secret that crypto/x509 does not know,
+	// such as Microsoft Document Signing or Smartcard Logon
+	UnknownExtKeyUsage []asn1.ObjectIdentifier `json:"-"`
 	// Public Key Algorithm of the x509 certificate in the Secret
-	PublicKeyAlgorithm x509.PublicKeyAlgorithm
+	PublicKeyAlgorithm x509.PublicKeyAlgorithm `json:"-"`
+	// Size in bits of the public key of the x509 certificate in the Secret, the modulus for RSA keys
+	KeyBits int `json:"keyBits,omitempty"`
+	// Curve of the public key of the x509 certificate in the Secret, empty for RSA keys
+	KeyCurve string `json:"keyCurve,omitempty"`
 	// Signature Algorithm of the x509 certificate in the Secret
-	SignatureAlgorithm x509.SignatureAlgorithm
+	SignatureAlgorithm x509.SignatureAlgorithm `json:"-"`
 	// Subject Key Id of the x509 certificate in the Secret
-	SubjectKeyId []byte
+	SubjectKeyId []byte `json:"-"`
 	// Authority Key Id of the x509 certificate in the Secret
-	AuthorityKeyId []byte
+	AuthorityKeyId []byte `json:"-"`
 	// Serial Number of the x509 certificate in the Secret
-	SerialNumber *big.Int
+	SerialNumber *big.Int `json:"-"`
+	// Version of the x509 certificate in the Secret, 3 for an x509 v3 certificate
+	Version int `json:"version,omitempty"`
+	// Not Before of the x509 certificate in the Secret
+	NotBefore time.Time `json:"notBefore"`
+	// Not After of the x509 certificate in the Secret
+	NotAfter time.Time `json:"notAfter"`
+	// Time until the x509 certificate in the Secret expires at the time the status was built,
+	// negative if already expired
+	ExpiresIn time.Duration `json:"-"`
+	// Whether the x509 certificate in the Secret was not yet valid at the time the status was built
+	NotYetValid bool `json:"notYetValid"`
+	// Whether the x509 certificate in the Secret had expired at the time the status was built
+	Expired bool `json:"expired"`
+	// Time the status was built at, from which the age of each event is computed.
+	// Must be set, as the events would otherwise be aged from year 1. The constructors set it from their clock
+	ObservedAt time.Time `json:"-"`
+	// SHA-256 fingerprint of the x509 certificate in the Secret, as colon separated hex
+	FingerprintSHA256 string `json:"fingerprintSHA256,omitempty"`
+	// SHA-1 fingerprint of the x509 certificate in the Secret, as colon separated hex
+	FingerprintSHA1 string `json:"fingerprintSHA1,omitempty"`
+	// Hash algorithm of the fingerprint printed, one of fingerprintAlgorithms. If empty, SHA-256 is printed
+	FingerprintAlgorithm string `json:"-"`
+	// If true, Serial Number is printed as the content of its DER INTEGER encoding,
+	// keeping the leading zero byte of serials with the high bit set as shown by most CAs
+	SerialNumberDER bool `json:"-"`
+	// If true, every field and extension of the x509 certificate in the Secret is printed, as with 'openssl x509 -text'
+	RawX509 bool `json:"-"`
+	// x509Cert is the parsed x509 certificate in the Secret, kept to print it in full if RawX509 is set
+	x509Cert *x509.Certificate
+	// Whether the x509 certificate in the Secret carries the Certificate Transparency
+	// precertificate poison extension, in which case it must never be served
+	IsPrecertificate bool `json:"isPrecertificate"`
+	// Whether the x509 certificate in the Secret has a Basic Constraints extension
+	BasicConstraintsValid bool `json:"basicConstraintsValid,omitempty"`
+	// Whether the Basic Constraints of the x509 certificate in the Secret mark it as a CA certificate
+	IsCA bool `json:"isCA,omitempty"`
+	// Maximum number of intermediate CA certificates that may follow the x509 certificate in the Secret
+	// in a chain, nil if it is not a CA certificate or its path length is not constrained
+	MaxPathLen *int `json:"maxPathLen,omitempty"`
+	// URLs of the OCSP servers in the Authority Information Access of the x509 certificate in the Secret
+	OCSPServer []string `json:"ocspServer,omitempty"`
+	// URLs of the certificate of the issuer in the Authority Information Access of the x509 certificate in the Secret
+	IssuingCertificateURL []string `json:"issuingCertificateURL,omitempty"`
+	// URLs of the CRL Distribution Points of the x509 certificate in the Secret
+	CRLDistributionPoints []string `json:"crlDistributionPoints,omitempty"`
+	// Whether the private key in 'tls.key' of the Secret matches the public key of the x509 certificate,
+	// nil if unknown because 'tls.key' is not set or could not be parsed
+	KeyMatchesCert *bool `json:"keyMatchesCert,omitempty"`
+	// If KeyError is not nil, 'tls.key' of the Secret could not be parsed
+	KeyError error `json:"-"`
+	// Subject and issuer of each certificate in 'tls.crt' of the Secret in the order stored,
+	// nil if it holds only the leaf certificate
+	Chain []ChainCertificateStatus `json:"chain,omitempty"`
+	// Whether the issuer of a certificate in Chain is not the subject of the certificate following it
+	ChainOutOfOrder bool `json:"chainOutOfOrder,omitempty"`
+	// Whether the x509 certificate in the Secret chains to 'ca.crt' of the Secret, or to the roots of the host
+	// if requested. If ChainVerified is false and ChainError is empty, there was nothing to verify against
+	ChainVerified bool `json:"chainVerified,omitempty"`
+	// Number of certificates in the verified chain, including the leaf and the root
+	ChainLength int `json:"chainLength,omitempty"`
+	// Why the x509 certificate in the Secret could not be verified
+	ChainError string `json:"chainError,omitempty"`
+	// Validity of the CA certificate that issued the x509 certificate in the Secret,
+	// nil if the Secret does not hold the CA certificate
+	CAValidity *CAValidityStatus `json:"caValidity,omitempty"`
+	// CA certificate in 'ca.crt' of the Secret, nil if 'ca.crt' is not set
+	CACertificate *CACertificateStatus `json:"caCertificate,omitempty"`
+	// Assessment of the signature algorithm against the --min-signature-strength policy,
+	// nil if no policy is configured
+	SignaturePolicy *SignaturePolicyStatus `json:"signaturePolicy,omitempty"`
+	// Name Constraints of the x509 certificate in the Secret, nil if it is not a CA certificate or has none
+	NameConstraints *NameConstraintsStatus `json:"nameConstraints,omitempty"`
+	// OIDs of the Certificate Policies of the x509 certificate in the Secret
+	PolicyIdentifiers []asn1.ObjectIdentifier `json:"-"`
+	// OIDs required by --require-policy that are not among the Certificate Policies, in dotted notation
+	MissingPolicies []string `json:"missingPolicies,omitempty"`
+	// Keystores in the Secret created for the keystores of the Certificate, nil if there are none
+	Keystores *KeystoresStatus `json:"keystores,omitempty"`
+	// Type of the Secret, which should be kubernetes.io/tls
+	Type v1.SecretType `json:"type,omitempty"`
+	// Whether the Secret has an owner reference to the Certificate, which cert-manager only sets
+	// if it runs with --enable-certificate-owner-ref
+	OwnedByCertificate bool `json:"ownedByCertificate"`
+	// Annotations of the Secret set by cert-manager, i.e. those with the cert-manager.io/ prefix
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Whether the cert-manager.io/certificate-name annotation of the Secret names another Certificate
+	// than the one whose status this is
+	CertificateNameMismatch bool `json:"certificateNameMismatch,omitempty"`
+	// Name of the file the Secret was read from with --from-file, empty if it was read from the cluster
+	File string `json:"file,omitempty"`
+	// Whether File is a PEM file rather than a Secret manifest, in which case the fields about the Secret resource are not set
+	PEMFile bool `json:"pemFile,omitempty"`
 	// Events of Secret resource
-	Events *v1.EventList
+	Events *v1.EventList `json:"events,omitempty"`
+	// If true, the events were not looked up and are not printed
+	HideEvents bool `json:"-"`
+	// Number of events left out of Events by --event-type
+	SuppressedEvents int `json:"suppressedEvents,omitempty"`
+}
+
+type ChainCertificateStatus struct {
+	// Subject of the certificate
+	Subject string `json:"subject"`
+	// Issuer of the certificate
+	Issuer string `json:"issuer"`
+}
+
+// NameConstraintsStatus holds the Name Constraints of a CA certificate, which limit the names
+// of the certificates it can issue
+type NameConstraintsStatus struct {
+	// Whether the Name Constraints extension is marked critical, so that clients which do not support it reject the certificate
+	Critical bool `json:"critical"`
+	// DNS domains the names of issued certificates must be in
+	PermittedDNSDomains []string `json:"permittedDNSDomains,omitempty"`
+	// DNS domains the names of issued certificates must not be in
+	ExcludedDNSDomains []string `json:"excludedDNSDomains,omitempty"`
+	// IP ranges the IP addresses of issued certificates must be in, in CIDR notation
+	PermittedIPRanges []string `json:"permittedIPRanges,omitempty"`
+	// IP ranges the IP addresses of issued certificates must not be in, in CIDR notation
+	ExcludedIPRanges []string `json:"excludedIPRanges,omitempty"`
+	// Email addresses, mailboxes or domains the email addresses of issued certificates must match
+	PermittedEmailAddresses []string `json:"permittedEmailAddresses,omitempty"`
+	// Email addresses, mailboxes or domains the email addresses of issued certificates must not match
+	ExcludedEmailAddresses []string `json:"excludedEmailAddresses,omitempty"`
+	// Domains the hosts of the URIs of issued certificates must be in
+	PermittedURIDomains []string `json:"permittedURIDomains,omitempty"`
+	// Domains the hosts of the URIs of issued certificates must not be in
+	ExcludedURIDomains []string `json:"excludedURIDomains,omitempty"`
+}
+
+type SignaturePolicyStatus struct {
+	// Strength tier of the signature algorithm of the x509 certificate in the Secret
+	Strength string `json:"strength"`
+	// Minimum strength tier required by the policy
+	MinStrength string `json:"minStrength"`
+	// Whether Strength meets MinStrength
+	Compliant bool `json:"compliant"`
+}
+
+type KeystoresStatus struct {
+	// Size in bytes of 'keystore.p12' of the Secret, zero if it is not set
+	PKCS12Size int `json:"pkcs12Size,omitempty"`
+	// Size in bytes of 'keystore.jks' of the Secret, zero if it is not set
+	JKSSize int `json:"jksSize,omitempty"`
+	// Whether the certificate in 'keystore.p12' is the x509 certificate in 'tls.crt',
+	// nil if not checked because no password was given or it could not be decoded
+	PKCS12MatchesCert *bool `json:"pkcs12MatchesCert,omitempty"`
+	// Why 'keystore.p12' could not be decoded with the password given
+	PKCS12Error string `json:"pkcs12Error,omitempty"`
+}
+
+type CAValidityStatus struct {
+	// Data key of the Secret the CA certificate was found in, either 'tls.crt' (as part of the chain) or 'ca.crt'
+	Source string `json:"source"`
+	// Not Before of the CA certificate
+	NotBefore time.Time `json:"notBefore"`
+	// Not After of the CA certificate
+	NotAfter time.Time `json:"notAfter"`
+	// Whether the x509 certificate in the Secret becomes valid before the CA certificate does
+	NotBeforePrecedesCA bool `json:"notBeforePrecedesCA"`
+	// Whether the x509 certificate in the Secret expires after the CA certificate does
+	NotAfterExceedsCA bool `json:"notAfterExceedsCA"`
+	// Time until which the x509 certificate in the Secret is effectively trusted,
+	// which is the earliest of its own Not After and the Not After of the CA certificate
+	TrustedUntil time.Time `json:"trustedUntil"`
+}
+
+type CACertificateStatus struct {
+	// If Error is not nil, 'ca.crt' of the Secret could not be parsed,
+	// so the rest of the fields is unusable
+	Error error `json:"-"`
+	// Subject Common Name of the CA certificate
+	SubjectCommonName string `json:"subjectCommonName"`
+	// Issuer Common Name of the CA certificate
+	IssuerCommonName string `json:"issuerCommonName"`
+	// Not After of the CA certificate
+	NotAfter time.Time `json:"notAfter"`
+	// Serial Number of the CA certificate
+	SerialNumber *big.Int `json:"-"`
 }
 
 type CRStatus struct {
 	// If Error is not nil, there was a problem getting the status of the CertificateRequest resource,
 	// so the rest of the fields is unusable
-	Error error
+	Error error `json:"-"`
 	// Name of the CertificateRequest resource
-	Name string
+	Name string `json:"name"`
 	// Namespace of the CertificateRequest resource
-	Namespace string
+	Namespace string `json:"namespace"`
+	// Revision of the Certificate the CertificateRequest resource was created for, zero if not annotated
+	Revision int `json:"revision,omitempty"`
+	// Creation time of the CertificateRequest resource
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+	// Time since the CertificateRequest resource was created at the time the status was built.
+	// Only set for the CertificateRequests in CRHistory
+	Age time.Duration `json:"-"`
 	// Conditions of CertificateRequest resource
-	Conditions []cmapi.CertificateRequestCondition
+	Conditions []cmapi.CertificateRequestCondition `json:"conditions,omitempty"`
+	// Time the status was built at, from which the age of each Condition and event is computed.
+	// Must be set, as the events would otherwise be aged from year 1. The constructors set it from their clock.
+	// If zero, only the Last Transition Time of the Conditions is printed
+	ObservedAt time.Time `json:"-"`
+	// What was requested by CertificateRequest resource, decoded from its certificate signing request
+	Requested *RequestedStatus `json:"requested,omitempty"`
 	// Events of CertificateRequest resource
-	Events *v1.EventList
+	Events *v1.EventList `json:"events,omitempty"`
+	// If true, the events were not looked up and are not printed
+	HideEvents bool `json:"-"`
+	// Number of events left out of Events by --event-type
+	SuppressedEvents int `json:"suppressedEvents,omitempty"`
+}
+
+type RequestedStatus struct {
+	// If Error is not nil, there was a problem decoding the certificate signing request,
+	// so the rest of the fields is unusable
+	Error error `json:"-"`
+	// Common Name of the certificate signing request
+	CommonName string `json:"commonName"`
+	// DNS Names of the certificate signing request
+	DNSNames []string `json:"dnsNames,omitempty"`
+	// IP Addresses of the certificate signing request
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+	// URIs of the certificate signing request
+	URIs []string `json:"uris,omitempty"`
+	// Email Addresses of the certificate signing request
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+	// Usages requested by CertificateRequest resource. If empty, the default usages apply
+	Usages []cmapi.KeyUsage `json:"usages,omitempty"`
+	// Whether CertificateRequest resource requests a CA certificate
+	IsCA bool `json:"isCA"`
 }
 
 type OrderStatus struct {
 	// If Error is not nil, there was a problem getting the status of the Order resource,
 	// so the rest of the fields is unusable
-	Error error
+	Error error `json:"-"`
 	// Name of the Order resource
-	Name string
+	Name string `json:"name"`
 	// State of Order resource
-	State cmacme.State
+	State cmacme.State `json:"state"`
 	// Reason why the Order resource is in its State
-	Reason string
+	Reason string `json:"reason"`
 	// What authorizations must be completed to validate the DNS names specified on the Order
-	Authorizations []cmacme.ACMEAuthorization
+	Authorizations []cmacme.ACMEAuthorization `json:"authorizations,omitempty"`
 	// Time the Order failed
-	FailureTime *metav1.Time
+	FailureTime *metav1.Time `json:"failureTime,omitempty"`
 }
 
 type ChallengeStatusList struct {
 	// If Error is not nil, there was a problem getting the status of the Order resource,
 	// so the rest of the fields is unusable
-	Error             error
-	ChallengeStatuses []*ChallengeStatus
+	Error             error              `json:"-"`
+	ChallengeStatuses []*ChallengeStatus `json:"items,omitempty"`
 }
 
 type ChallengeStatus struct {
-	Name       string
-	Type       cmacme.ACMEChallengeType
-	Token      string
-	Key        string
-	State      cmacme.State
-	Reason     string
-	Processing bool
-	Presented  bool
+	Name       string                   `json:"name"`
+	Type       cmacme.ACMEChallengeType `json:"type"`
+	DNSName    string                   `json:"dnsName"`
+	Token      string                   `json:"token"`
+	Key        string                   `json:"key"`
+	State      cmacme.State             `json:"state"`
+	Reason     string                   `json:"reason"`
+	Processing bool                     `json:"processing"`
+	Presented  bool                     `json:"presented"`
+	// Live lookup of the TXT record of a DNS01 challenge in progress, nil if not checked
+	DNSCheck *DNSCheckStatus `json:"dnsCheck,omitempty"`
+}
+
+type DNSCheckStatus struct {
+	// Fully qualified name of the TXT record presented for the challenge
+	FQDN string `json:"fqdn"`
+	// Value the TXT record is expected to have
+	Expected string `json:"expected"`
+	// Lookups of the TXT record, one per nameserver queried
+	Lookups []DNSLookup `json:"lookups"`
 }
 
-func newCertificateStatusFromCert(crt *cmapi.Certificate) *CertificateStatus {
+func newCertificateStatusFromCert(crt *cmapi.Certificate, clock clock.Clock) *CertificateStatus {
 	if crt == nil {
 		return nil
 	}
 	return &CertificateStatus{
 		Name: crt.Name, Namespace: crt.Namespace, CreationTime: crt.CreationTimestamp,
-		Conditions: crt.Status.Conditions, DNSNames: crt.Spec.DNSNames,
-		NotBefore: crt.Status.NotBefore, NotAfter: crt.Status.NotAfter, RenewalTime: crt.Status.RenewalTime}
+		Revision: crt.Status.Revision, Issuing: apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+			Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+		Conditions: crt.Status.Conditions, DNSNames: crt.Spec.DNSNames, IsCA: crt.Spec.IsCA, SecretName: crt.Spec.SecretName,
+		NotBefore: crt.Status.NotBefore, NotAfter: crt.Status.NotAfter, RenewalTime: crt.Status.RenewalTime,
+		ObservedAt:        clock.Now(),
+		Validity:          newValidityStatus(crt.Status.NotBefore, crt.Status.NotAfter, crt.Status.RenewalTime, clock.Now()),
+		RequestedDuration: newDurationStatus(apiutil.DefaultCertDuration(crt.Spec.Duration))}
+}
+
+// newValidityStatus computes the durations of the validity of a Certificate relative to now.
+// Returns nil if none of them can be computed.
+func newValidityStatus(notBefore, notAfter, renewalTime *metav1.Time, now time.Time) *ValidityStatus {
+	validity := &ValidityStatus{}
+	if notAfter != nil {
+		validity.ExpiresIn = newDurationStatus(notAfter.Sub(now))
+		if notBefore != nil {
+			validity.Lifetime = newDurationStatus(notAfter.Sub(notBefore.Time))
+		}
+	}
+	if renewalTime != nil {
+		validity.RenewalIn = newDurationStatus(renewalTime.Sub(now))
+		validity.RenewalOverdue = renewalTime.Time.Before(now)
+		validity.RenewalAfterExpiry = notAfter != nil && renewalTime.Time.After(notAfter.Time)
+	}
+	if validity.ExpiresIn == nil && validity.RenewalIn == nil {
+		return nil
+	}
+	return validity
+}
+
+func newDurationStatus(d time.Duration) *DurationStatus {
+	return &DurationStatus{Seconds: int64(d / time.Second), ISO8601: formatISO8601Duration(d)}
+}
+
+// formatISO8601Duration formats d as an ISO 8601 duration, e.g. P12DT3H4M5S.
+// Days are the largest unit used, since months and years do not have a fixed length.
+// Fractions of seconds are truncated and negative durations are prefixed with '-'.
+func formatISO8601Duration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	days := int64(d / (24 * time.Hour))
+	hours := int64(d/time.Hour) % 24
+	minutes := int64(d/time.Minute) % 60
+	seconds := int64(d/time.Second) % 60
+
+	output := sign + "P"
+	if days > 0 {
+		output += fmt.Sprintf("%dD", days)
+	}
+	if hours > 0 || minutes > 0 || seconds > 0 {
+		output += "T"
+		if hours > 0 {
+			output += fmt.Sprintf("%dH", hours)
+		}
+		if minutes > 0 {
+			output += fmt.Sprintf("%dM", minutes)
+		}
+		if seconds > 0 {
+			output += fmt.Sprintf("%dS", seconds)
+		}
+	}
+	if days == 0 && hours == 0 && minutes == 0 && seconds == 0 {
+		return "PT0S"
+	}
+	return output
 }
 
 func (status *CertificateStatus) withEvents(events *v1.EventList) *CertificateStatus {
@@ -172,7 +637,7 @@ func (status *CertificateStatus) withEvents(events *v1.EventList) *CertificateSt
 	return status
 }
 
-func (status *CertificateStatus) withGenericIssuer(genericIssuer cmapi.GenericIssuer, issuerKind string, issuerEvents *v1.EventList, err error) *CertificateStatus {
+func (status *CertificateStatus) withGenericIssuer(genericIssuer cmapi.GenericIssuer, issuerKind string, issuerEvents *v1.EventList, err error, clock clock.Clock) *CertificateStatus {
 	if err != nil {
 		status.IssuerStatus = &IssuerStatus{Error: err}
 		return status
@@ -180,330 +645,2131 @@ func (status *CertificateStatus) withGenericIssuer(genericIssuer cmapi.GenericIs
 	if genericIssuer == nil {
 		return status
 	}
+	issuerType, endpoint := issuerTypeAndEndpoint(genericIssuer.GetSpec())
 	if issuerKind == "ClusterIssuer" {
-		status.IssuerStatus = &IssuerStatus{Name: genericIssuer.GetName(), Kind: "ClusterIssuer",
-			Conditions: genericIssuer.GetStatus().Conditions, Events: issuerEvents}
+		status.IssuerStatus = &IssuerStatus{Name: genericIssuer.GetName(), Kind: "ClusterIssuer", Type: issuerType, Endpoint: endpoint,
+			Conditions: genericIssuer.GetStatus().Conditions, ObservedAt: clock.Now(), Events: issuerEvents}
 		return status
 	}
-	status.IssuerStatus = &IssuerStatus{Name: genericIssuer.GetName(), Kind: "Issuer",
-		Conditions: genericIssuer.GetStatus().Conditions, Events: issuerEvents}
+	status.IssuerStatus = &IssuerStatus{Name: genericIssuer.GetName(), Kind: "Issuer", Type: issuerType, Endpoint: endpoint,
+		Conditions: genericIssuer.GetStatus().Conditions, ObservedAt: clock.Now(), Events: issuerEvents}
 	return status
 }
 
-func (status *CertificateStatus) withSecret(secret *v1.Secret, secretEvents *v1.EventList, err error) *CertificateStatus {
-	if err != nil {
-		status.SecretStatus = &SecretStatus{Error: err}
-		return status
-	}
-	if secret == nil {
-		return status
+// issuerTypeAndEndpoint returns the type of an Issuer/ClusterIssuer with spec, after the config set in it,
+// and where it gets certificates from. Both are empty if spec sets none of the types known to this command
+func issuerTypeAndEndpoint(spec *cmapi.IssuerSpec) (string, string) {
+	switch {
+	case spec == nil:
+		return "", ""
+	case spec.ACME != nil:
+		return "ACME", spec.ACME.Server
+	case spec.CA != nil:
+		return "CA", fmt.Sprintf("Secret %q", spec.CA.SecretName)
+	case spec.Vault != nil:
+		return "Vault", fmt.Sprintf("%s, path %s", spec.Vault.Server, spec.Vault.Path)
+	case spec.Venafi != nil && spec.Venafi.TPP != nil:
+		return "Venafi", fmt.Sprintf("TPP %s, zone %s", spec.Venafi.TPP.URL, spec.Venafi.Zone)
+	case spec.Venafi != nil && spec.Venafi.Cloud != nil && spec.Venafi.Cloud.URL != "":
+		return "Venafi", fmt.Sprintf("Venafi Cloud %s, zone %s", spec.Venafi.Cloud.URL, spec.Venafi.Zone)
+	case spec.Venafi != nil:
+		return "Venafi", "Venafi Cloud, zone " + spec.Venafi.Zone
+	case spec.SelfSigned != nil:
+		return "SelfSigned", ""
+	default:
+		return "", ""
 	}
-	certData := secret.Data["tls.crt"]
+}
 
-	if len(certData) == 0 {
-		status.SecretStatus = &SecretStatus{Error: fmt.Errorf("error: 'tls.crt' of Secret %q is not set\n", secret.Name)}
+// withIssuerRef records the API group of the issuer referenced by ref. An external issuer is not looked up,
+// so its status only holds the reference
+func (status *CertificateStatus) withIssuerRef(ref cmmeta.ObjectReference, group string, external bool) *CertificateStatus {
+	if external {
+		status.IssuerStatus = &IssuerStatus{Name: ref.Name, Kind: ref.Kind, Group: group, External: true}
 		return status
 	}
-
-	x509Cert, err := pki.DecodeX509CertificateBytes(certData)
-	if err != nil {
-		status.SecretStatus = &SecretStatus{Error: fmt.Errorf("error when parsing 'tls.crt' of Secret %q: %s\n", secret.Name, err)}
-		return status
+	if status.IssuerStatus != nil && status.IssuerStatus.Error == nil {
+		status.IssuerStatus.Group = group
 	}
+	return status
+}
 
-	status.SecretStatus = &SecretStatus{Error: nil, Name: secret.Name, IssuerCountry: x509Cert.Issuer.Country,
-		IssuerOrganisation: x509Cert.Issuer.Organization,
-		IssuerCommonName:   x509Cert.Issuer.CommonName, KeyUsage: x509Cert.KeyUsage,
-		ExtKeyUsage: x509Cert.ExtKeyUsage, PublicKeyAlgorithm: x509Cert.PublicKeyAlgorithm,
-		SignatureAlgorithm: x509Cert.SignatureAlgorithm,
-		SubjectKeyId:       x509Cert.SubjectKeyId, AuthorityKeyId: x509Cert.AuthorityKeyId,
-		SerialNumber: x509Cert.SerialNumber, Events: secretEvents}
+func (status *CertificateStatus) withSecretContention(names []string) *CertificateStatus {
+	status.SecretContention = names
 	return status
 }
 
-func (status *CertificateStatus) withCR(req *cmapi.CertificateRequest, events *v1.EventList, err error) *CertificateStatus {
-	if err != nil {
-		status.CRStatus = &CRStatus{Error: err}
-		return status
-	}
-	if req == nil {
+func (status *CertificateStatus) withSameNameIssuers(namespaces []string) *CertificateStatus {
+	if status.IssuerStatus == nil || status.IssuerStatus.Error != nil {
 		return status
 	}
-	status.CRStatus = &CRStatus{Name: req.Name, Namespace: req.Namespace, Conditions: req.Status.Conditions, Events: events}
+	status.IssuerStatus.SameNameIssuerNamespaces = namespaces
 	return status
 }
 
-func (status *CertificateStatus) withOrder(order *cmacme.Order, err error) *CertificateStatus {
+func (status *CertificateStatus) withIssuerCA(caSecret *v1.Secret, err error, clock clock.Clock) *CertificateStatus {
+	if status.IssuerStatus == nil || status.IssuerStatus.Error != nil {
+		return status
+	}
 	if err != nil {
-		status.OrderStatus = &OrderStatus{Error: err}
+		status.IssuerStatus.CAStatus = &IssuerCAStatus{Error: err}
 		return status
 	}
-	if order == nil {
+	if caSecret == nil {
 		return status
 	}
 
-	status.OrderStatus = &OrderStatus{Name: order.Name, State: order.Status.State,
-		Reason: order.Status.Reason, Authorizations: order.Status.Authorizations,
-		FailureTime: order.Status.FailureTime}
+	caCert, err := pki.DecodeX509CertificateBytes(caSecret.Data["tls.crt"])
+	if err != nil {
+		status.IssuerStatus.CAStatus = &IssuerCAStatus{Error: fmt.Errorf("error when parsing 'tls.crt' of CA Secret %q: %s\n", caSecret.Name, err)}
+		return status
+	}
+	status.IssuerStatus.CAStatus = &IssuerCAStatus{SecretName: caSecret.Name, NotAfter: caCert.NotAfter,
+		ExpiresIn: caCert.NotAfter.Sub(clock.Now())}
 	return status
 }
 
-func (status *CertificateStatus) withChallenges(challenges []*cmacme.Challenge, err error) *CertificateStatus {
+// withNextPrivateKey sets the status of the next private key of the Certificate, stored in 'tls.key' of secret.
+// err is the error getting secret, which is nil if status.nextPrivateKeySecretName is not set.
+func (status *CertificateStatus) withNextPrivateKey(secret *v1.Secret, err error) *CertificateStatus {
 	if err != nil {
-		status.ChallengeStatusList = &ChallengeStatusList{Error: err}
+		status.NextPrivateKeyStatus = &NextPrivateKeyStatus{Error: err}
 		return status
 	}
-	if len(challenges) == 0 {
+	if secret == nil {
 		return status
 	}
 
-	var list []*ChallengeStatus
-	for _, challenge := range challenges {
-		list = append(list, &ChallengeStatus{
-			Name:       challenge.Name,
-			Type:       challenge.Spec.Type,
-			Token:      challenge.Spec.Token,
-			Key:        challenge.Spec.Key,
-			State:      challenge.Status.State,
-			Reason:     challenge.Status.Reason,
-			Processing: challenge.Status.Processing,
-			Presented:  challenge.Status.Presented,
-		})
+	key, err := pki.DecodePrivateKeyBytes(secret.Data[v1.TLSPrivateKeyKey])
+	if err != nil {
+		status.NextPrivateKeyStatus = &NextPrivateKeyStatus{Error: fmt.Errorf("error when parsing 'tls.key' of next private key Secret %q: %s\n", secret.Name, err)}
+		return status
 	}
-	status.ChallengeStatusList = &ChallengeStatusList{ChallengeStatuses: list}
+	status.NextPrivateKeyStatus = &NextPrivateKeyStatus{SecretName: secret.Name, KeyAlgorithm: privateKeyAlgorithm(key)}
+	status.NextPrivateKeyStatus.KeyBits, status.NextPrivateKeyStatus.KeyCurve = publicKeySize(key.Public())
 	return status
 }
 
-func (status *CertificateStatus) String() string {
-	output := ""
-	output += fmt.Sprintf("Name: %s\n", status.Name)
-	output += fmt.Sprintf("Namespace: %s\n", status.Namespace)
-	output += fmt.Sprintf("Created at: %s\n", formatTimeString(&status.CreationTime))
-
-	// Output one line about each type of Condition that is set.
-	// Certificate can have multiple Conditions of different types set, e.g. "Ready" or "Issuing"
-	conditionMsg := ""
-	for _, con := range status.Conditions {
-		conditionMsg += fmt.Sprintf("  %s: %s, Reason: %s, Message: %s\n", con.Type, con.Status, con.Reason, con.Message)
+// privateKeyAlgorithm returns the algorithm of key as the public key algorithm of a certificate for it
+func privateKeyAlgorithm(key crypto.Signer) x509.PublicKeyAlgorithm {
+	switch key.Public().(type) {
+	case *rsa.PublicKey:
+		return x509.RSA
+	case *ecdsa.PublicKey:
+		return x509.ECDSA
+	case ed25519.PublicKey:
+		return x509.Ed25519
+	}
+	return x509.UnknownPublicKeyAlgorithm
+}
+
+func (status *CertificateStatus) withSecret(secret *v1.Secret, secretEvents *v1.EventList, err error, clock clock.Clock) *CertificateStatus {
+	if err != nil && apierrors.IsNotFound(err) {
+		status.SecretStatus = &SecretStatus{Error: &secretNotCreatedError{name: status.SecretName, issuing: status.Issuing, err: err},
+			NotCreated: true, Name: status.SecretName}
+		return status
+	}
+	if err != nil {
+		status.SecretStatus = &SecretStatus{Error: err}
+		return status
 	}
-	if conditionMsg == "" {
-		conditionMsg = "  No Conditions set\n"
+	if secret == nil {
+		return status
 	}
-	output += fmt.Sprintf("Conditions:\n%s", conditionMsg)
+	certData := secret.Data["tls.crt"]
 
-	output += fmt.Sprintf("DNS Names:\n%s", formatStringSlice(status.DNSNames))
+	if len(certData) == 0 {
+		status.SecretStatus = &SecretStatus{Error: fmt.Errorf("error: 'tls.crt' of Secret %q is not set\n", secret.Name)}
+		return status
+	}
 
-	output += eventsToString(status.Events, 0)
+	chain, err := pki.DecodeX509CertificateChainBytes(certData)
+	if err != nil {
+		status.SecretStatus = &SecretStatus{Error: fmt.Errorf("error when parsing 'tls.crt' of Secret %q: %s\n", secret.Name, err)}
+		return status
+	}
+	// The fields of the status describe the leaf, which comes first
+	x509Cert := chain[0]
 
-	output += status.IssuerStatus.String()
-	output += status.SecretStatus.String()
+	sha256Sum, sha1Sum := sha256.Sum256(x509Cert.Raw), sha1.Sum(x509Cert.Raw)
+	status.SecretStatus = &SecretStatus{Error: nil, Name: secret.Name, IssuerCountry: x509Cert.Issuer.Country,
+		IssuerOrganisation: x509Cert.Issuer.Organization,
+		IssuerCommonName:   x509Cert.Issuer.CommonName, SubjectCountry: x509Cert.Subject.Country,
+		SubjectOrganisation: x509Cert.Subject.Organization, SubjectOrganizationalUnit: x509Cert.Subject.OrganizationalUnit,
+		SubjectCommonName: x509Cert.Subject.CommonName,
+		DNSNames:          x509Cert.DNSNames, IPAddresses: pki.IPAddressesToString(x509Cert.IPAddresses),
+		URIs: pki.URLsToString(x509Cert.URIs), EmailAddresses: x509Cert.EmailAddresses, KeyUsage: x509Cert.KeyUsage,
+		ExtKeyUsage: x509Cert.ExtKeyUsage, UnknownExtKeyUsage: x509Cert.UnknownExtKeyUsage, PublicKeyAlgorithm: x509Cert.PublicKeyAlgorithm,
+		SignatureAlgorithm: x509Cert.SignatureAlgorithm, Version: x509Cert.Version,
+		SubjectKeyId: x509Cert.SubjectKeyId, AuthorityKeyId: x509Cert.AuthorityKeyId,
+		SerialNumber:      x509Cert.SerialNumber,
+		FingerprintSHA256: formatHexColon(sha256Sum[:]), FingerprintSHA1: formatHexColon(sha1Sum[:]),
+		IsPrecertificate:      hasCTPoisonExtension(x509Cert),
+		BasicConstraintsValid: x509Cert.BasicConstraintsValid, IsCA: x509Cert.IsCA, MaxPathLen: maxPathLen(x509Cert),
+		OCSPServer: x509Cert.OCSPServer, IssuingCertificateURL: x509Cert.IssuingCertificateURL,
+		CRLDistributionPoints: x509Cert.CRLDistributionPoints,
+		CAValidity:            newCAValidityStatus(x509Cert, secret), CACertificate: newCACertificateStatus(secret),
+		PolicyIdentifiers: x509Cert.PolicyIdentifiers, NameConstraints: newNameConstraintsStatus(x509Cert),
+		Type:        secret.Type,
+		Annotations: certManagerAnnotations(secret.Annotations), Events: secretEvents, x509Cert: x509Cert}
 
-	output += fmt.Sprintf("Not Before: %s\n", formatTimeString(status.NotBefore))
-	output += fmt.Sprintf("Not After: %s\n", formatTimeString(status.NotAfter))
-	output += fmt.Sprintf("Renewal Time: %s\n", formatTimeString(status.RenewalTime))
+	status.SecretStatus.KeyBits, status.SecretStatus.KeyCurve = publicKeySize(x509Cert.PublicKey)
+	status.SecretStatus.KeyMatchesCert, status.SecretStatus.KeyError = keyMatchesCert(secret, x509Cert)
+	status.SecretStatus.Chain, status.SecretStatus.ChainOutOfOrder = newChainStatus(chain)
+	status.SecretStatus.Keystores = newKeystoresStatus(secret)
+	if crtName, ok := secret.Annotations[cmapi.CertificateNameKey]; ok && status.Name != "" && crtName != status.Name {
+		status.SecretStatus.CertificateNameMismatch = true
+	}
 
-	output += status.CRStatus.String()
+	now := clock.Now()
+	status.SecretStatus.NotBefore = x509Cert.NotBefore
+	status.SecretStatus.NotAfter = x509Cert.NotAfter
+	status.SecretStatus.ExpiresIn = x509Cert.NotAfter.Sub(now)
+	status.SecretStatus.NotYetValid = now.Before(x509Cert.NotBefore)
+	status.SecretStatus.Expired = now.After(x509Cert.NotAfter)
+	status.SecretStatus.ObservedAt = now
 
-	// OrderStatus is nil is not found or Issuer/ClusterIssuer is not ACME Issuer
-	if status.OrderStatus != nil {
-		output += status.OrderStatus.String()
+	actualDuration := x509Cert.NotAfter.Sub(x509Cert.NotBefore)
+	status.ActualDuration = newDurationStatus(actualDuration)
+	if status.RequestedDuration != nil {
+		requestedDuration := time.Duration(status.RequestedDuration.Seconds) * time.Second
+		status.DurationShortened = actualDuration < requestedDuration-durationShortenedTolerance
 	}
+	return status
+}
+
+// secretNotCreatedError replaces the NotFound error of the API server when the Secret does not exist yet,
+// so that it does not read like a failure. It unwraps to the NotFound error, so apierrors.IsNotFound still holds
+type secretNotCreatedError struct {
+	name    string
+	issuing bool
+	err     error
+}
 
-	if status.ChallengeStatusList != nil {
-		output += status.ChallengeStatusList.String()
+func (e *secretNotCreatedError) Error() string {
+	if e.issuing {
+		return fmt.Sprintf("Secret %q not created yet (issuance in progress)\n", e.name)
 	}
+	return fmt.Sprintf("Secret %q not created yet\n", e.name)
+}
 
-	return output
+func (e *secretNotCreatedError) Unwrap() error {
+	return e.err
 }
 
-// String returns the information about the status of a Issuer/ClusterIssuer as a string to be printed as output
-func (issuerStatus *IssuerStatus) String() string {
-	if issuerStatus.Error != nil {
-		return issuerStatus.Error.Error()
+// durationShortenedTolerance is how much shorter than requested the duration of a certificate can be
+// without being reported as shortened, since issuers such as Let's Encrypt set Not After a second early
+const durationShortenedTolerance = time.Minute
+
+// writeAuthorityInformation writes where revocation and the certificate of the issuer of the x509 certificate
+// in the Secret can be looked up, omitting the kinds of URL the certificate has none of.
+// Nothing is written if it has none at all, as is the case for most private CAs.
+func (secretStatus *SecretStatus) writeAuthorityInformation(sw *statusWriter) {
+	if len(secretStatus.OCSPServer) == 0 && len(secretStatus.IssuingCertificateURL) == 0 && len(secretStatus.CRLDistributionPoints) == 0 {
+		return
+	}
+	fmt.Fprint(sw, "  Authority Information:\n")
+	if len(secretStatus.OCSPServer) > 0 {
+		fmt.Fprintf(sw, "    OCSP Servers: %s\n", joinSorted(secretStatus.OCSPServer))
+	}
+	if len(secretStatus.IssuingCertificateURL) > 0 {
+		fmt.Fprintf(sw, "    Issuing Certificate URLs: %s\n", joinSorted(secretStatus.IssuingCertificateURL))
 	}
+	if len(secretStatus.CRLDistributionPoints) > 0 {
+		fmt.Fprintf(sw, "    CRL Distribution Points: %s\n", joinSorted(secretStatus.CRLDistributionPoints))
+	}
+}
 
-	issuerFormat := `Issuer:
-  Name: %s
-  Kind: %s
-  Conditions:
-  %s`
-	conditionMsg := ""
-	for _, con := range issuerStatus.Conditions {
-		conditionMsg += fmt.Sprintf("  %s: %s, Reason: %s, Message: %s\n", con.Type, con.Status, con.Reason, con.Message)
+// newNameConstraintsStatus returns the Name Constraints of cert, or nil if it is not a CA certificate
+// or has no Name Constraints
+func newNameConstraintsStatus(cert *x509.Certificate) *NameConstraintsStatus {
+	if !cert.IsCA {
+		return nil
 	}
-	if conditionMsg == "" {
-		conditionMsg = "  No Conditions set\n"
+	constraints := &NameConstraintsStatus{Critical: cert.PermittedDNSDomainsCritical,
+		PermittedDNSDomains: cert.PermittedDNSDomains, ExcludedDNSDomains: cert.ExcludedDNSDomains,
+		PermittedIPRanges: ipNetsToStrings(cert.PermittedIPRanges), ExcludedIPRanges: ipNetsToStrings(cert.ExcludedIPRanges),
+		PermittedEmailAddresses: cert.PermittedEmailAddresses, ExcludedEmailAddresses: cert.ExcludedEmailAddresses,
+		PermittedURIDomains: cert.PermittedURIDomains, ExcludedURIDomains: cert.ExcludedURIDomains}
+	if len(constraints.PermittedDNSDomains)+len(constraints.ExcludedDNSDomains)+
+		len(constraints.PermittedIPRanges)+len(constraints.ExcludedIPRanges)+
+		len(constraints.PermittedEmailAddresses)+len(constraints.ExcludedEmailAddresses)+
+		len(constraints.PermittedURIDomains)+len(constraints.ExcludedURIDomains) == 0 {
+		return nil
 	}
-	output := fmt.Sprintf(issuerFormat, issuerStatus.Name, issuerStatus.Kind, conditionMsg)
-	output += eventsToString(issuerStatus.Events, 1)
-	return output
+	return constraints
 }
 
-// String returns the information about the status of a Secret as a string to be printed as output
-func (secretStatus *SecretStatus) String() string {
-	if secretStatus.Error != nil {
-		return secretStatus.Error.Error()
+// ipNetsToStrings returns the IP ranges in CIDR notation
+func ipNetsToStrings(ipNets []*net.IPNet) []string {
+	var ipNetStrings []string
+	for _, ipNet := range ipNets {
+		ipNetStrings = append(ipNetStrings, ipNet.String())
 	}
+	return ipNetStrings
+}
 
-	secretFormat := `Secret:
-  Name: %s
-  Issuer Country: %s
-  Issuer Organisation: %s
-  Issuer Common Name: %s
-  Key Usage: %s
-  Extended Key Usages: %s
-  Public Key Algorithm: %s
-  Signature Algorithm: %s
-  Subject Key ID: %s
-  Authority Key ID: %s
-  Serial Number: %s
-`
+// writeNameConstraints writes the Name Constraints of the x509 certificate in the Secret as a block,
+// omitting the kinds of names that are not constrained. Nothing is written if there are none
+func (secretStatus *SecretStatus) writeNameConstraints(sw *statusWriter) {
+	constraints := secretStatus.NameConstraints
+	if constraints == nil {
+		return
+	}
+	fmt.Fprintf(sw, "  Name Constraints:\n    Critical: %t\n", constraints.Critical)
+	for _, constraint := range []struct {
+		name  string
+		names []string
+	}{
+		{"Permitted DNS Domains", constraints.PermittedDNSDomains},
+		{"Excluded DNS Domains", constraints.ExcludedDNSDomains},
+		{"Permitted IP Ranges", constraints.PermittedIPRanges},
+		{"Excluded IP Ranges", constraints.ExcludedIPRanges},
+		{"Permitted Email Addresses", constraints.PermittedEmailAddresses},
+		{"Excluded Email Addresses", constraints.ExcludedEmailAddresses},
+		{"Permitted URI Domains", constraints.PermittedURIDomains},
+		{"Excluded URI Domains", constraints.ExcludedURIDomains},
+	} {
+		if len(constraint.names) > 0 {
+			fmt.Fprintf(sw, "    %s: %s\n", constraint.name, strings.Join(constraint.names, ", "))
+		}
+	}
+}
 
-	extKeyUsageString, err := extKeyUsageToString(secretStatus.ExtKeyUsage)
-	if err != nil {
-		extKeyUsageString = err.Error()
-	}
-	output := fmt.Sprintf(secretFormat, secretStatus.Name, strings.Join(secretStatus.IssuerCountry, ", "),
-		strings.Join(secretStatus.IssuerOrganisation, ", "),
-		secretStatus.IssuerCommonName, keyUsageToString(secretStatus.KeyUsage),
-		extKeyUsageString, secretStatus.PublicKeyAlgorithm, secretStatus.SignatureAlgorithm,
-		hex.EncodeToString(secretStatus.SubjectKeyId), hex.EncodeToString(secretStatus.AuthorityKeyId),
-		hex.EncodeToString(secretStatus.SerialNumber.Bytes()))
-	output += eventsToString(secretStatus.Events, 1)
-	return output
+// basicConstraints returns the Basic Constraints of the x509 certificate in the Secret as printed
+func (secretStatus *SecretStatus) basicConstraints() string {
+	switch {
+	case !secretStatus.BasicConstraintsValid:
+		return "not set"
+	case !secretStatus.IsCA:
+		return "CA: false"
+	case secretStatus.MaxPathLen == nil:
+		return "CA: true, Max Path Length: unlimited"
+	default:
+		return fmt.Sprintf("CA: true, Max Path Length: %d", *secretStatus.MaxPathLen)
+	}
 }
 
-var (
-	keyUsageToStringMap = map[int]string{
-		1:   "Digital Signature",
-		2:   "Content Commitment",
-		4:   "Key Encipherment",
-		8:   "Data Encipherment",
-		16:  "Key Agreement",
-		32:  "Cert Sign",
-		64:  "CRL Sign",
-		128: "Encipher Only",
-		256: "Decipher Only",
+// maxPathLen returns the path length constraint of x509Cert, nil if it is not a CA certificate or sets none.
+// crypto/x509 parses a missing constraint as -1 and an explicit zero as 0 with MaxPathLenZero set.
+func maxPathLen(x509Cert *x509.Certificate) *int {
+	if !x509Cert.IsCA || x509Cert.MaxPathLen < 0 || (x509Cert.MaxPathLen == 0 && !x509Cert.MaxPathLenZero) {
+		return nil
 	}
-	keyUsagePossibleValues  = []int{256, 128, 64, 32, 16, 8, 4, 2, 1}
-	extKeyUsageStringValues = []string{"Any", "Server Authentication", "Client Authentication", "Code Signing", "Email Protection",
-		"IPSEC End System", "IPSEC Tunnel", "IPSEC User", "Time Stamping", "OCSP Signing", "Microsoft Server Gated Crypto",
-		"Netscape Server Gated Crypto", "Microsoft Commercial Code Signing", "Microsoft Kernel Code Signing",
+	pathLen := x509Cert.MaxPathLen
+	return &pathLen
+}
+
+// wrongType returns true if the type of the Secret is known and is not kubernetes.io/tls
+func (secretStatus *SecretStatus) wrongType() bool {
+	return secretStatus.Type != "" && secretStatus.Type != v1.SecretTypeTLS
+}
+
+// withSecretOwner records whether secret is controlled by crt. The owner reference is matched by UID,
+// so a Secret left behind by a deleted Certificate of the same name is not owned by crt
+func (status *CertificateStatus) withSecretOwner(secret *v1.Secret, crt *cmapi.Certificate) *CertificateStatus {
+	if secret == nil || crt == nil || status.SecretStatus == nil || status.SecretStatus.Error != nil {
+		return status
 	}
-)
+	status.SecretStatus.OwnedByCertificate = predicate.ResourceOwnedBy(crt)(secret)
+	return status
+}
 
-func keyUsageToString(usage x509.KeyUsage) string {
-	usageInt := int(usage)
-	var usageStrings []string
-	for _, val := range keyUsagePossibleValues {
-		if usageInt >= val {
-			usageInt -= val
-			usageStrings = append(usageStrings, keyUsageToStringMap[val])
+// certManagerAnnotationPrefix is the prefix of the annotations cert-manager sets on the Secrets it manages
+const certManagerAnnotationPrefix = "cert-manager.io/"
+
+// certManagerAnnotations returns the annotations with certManagerAnnotationPrefix, or nil if there are none
+func certManagerAnnotations(annotations map[string]string) map[string]string {
+	var result map[string]string
+	for key, value := range annotations {
+		if !strings.HasPrefix(key, certManagerAnnotationPrefix) {
+			continue
 		}
-		if usageInt == 0 {
-			break
+		if result == nil {
+			result = map[string]string{}
 		}
+		result[key] = value
+	}
+	return result
+}
+
+const (
+	// renewalStateOK is the RenewalState of a Certificate before its Renewal Time
+	renewalStateOK = "OK"
+	// renewalStateDueForRenewal is the RenewalState of a Certificate past its Renewal Time that is being reissued
+	renewalStateDueForRenewal = "DueForRenewal"
+	// renewalStateOverdue is the RenewalState of a Certificate past its Renewal Time that is not being reissued,
+	// or whose certificate has expired, which points at the controller not acting on the renewal
+	renewalStateOverdue = "Overdue"
+)
+
+// withRenewalState compares the time of clock with the Renewal Time of the Certificate and Not After of the
+// x509 certificate in the Secret to tell whether the Certificate should already be renewing
+func (status *CertificateStatus) withRenewalState(clock clock.Clock) *CertificateStatus {
+	now := clock.Now()
+	expired := status.SecretStatus != nil && status.SecretStatus.Error == nil && now.After(status.SecretStatus.NotAfter)
+	if status.RenewalTime == nil && !expired {
+		return status
 	}
-	// Reversing because that's usually the order the usages are printed
-	for i := 0; i < len(usageStrings)/2; i++ {
-		opp := len(usageStrings) - 1 - i
-		usageStrings[i], usageStrings[opp] = usageStrings[opp], usageStrings[i]
+	due := status.RenewalTime != nil && !now.Before(status.RenewalTime.Time)
+	switch {
+	case expired || (due && !status.Issuing):
+		status.RenewalState = renewalStateOverdue
+	case due:
+		status.RenewalState = renewalStateDueForRenewal
+	default:
+		status.RenewalState = renewalStateOK
 	}
-	return strings.Join(usageStrings, ", ")
+	return status
 }
 
-func extKeyUsageToString(extUsages []x509.ExtKeyUsage) (string, error) {
-	var extUsageStrings []string
-	for _, extUsage := range extUsages {
-		if extUsage < 0 || int(extUsage) >= len(extKeyUsageStringValues) {
-			return "", fmt.Errorf("error when converting Extended Usages to string: encountered unknown Extended Usage with code %d", extUsage)
-		}
-		extUsageStrings = append(extUsageStrings, extKeyUsageStringValues[extUsage])
+// writeRenewalState writes the RenewalState of the Certificate with what it means, if it is known
+func (status *CertificateStatus) writeRenewalState(sw *statusWriter) {
+	switch status.RenewalState {
+	case renewalStateOK:
+		fmt.Fprint(sw, "Renewal State: OK\n")
+	case renewalStateDueForRenewal:
+		fmt.Fprint(sw, "Renewal State: DueForRenewal, the Renewal Time has passed and the Certificate is being reissued\n")
+	case renewalStateOverdue:
+		fmt.Fprintf(sw, "Renewal State: %s\n", sw.colorize(colorRed,
+			"Overdue, the Certificate should have been renewed but is not being reissued or its certificate has expired"))
 	}
-	return strings.Join(extUsageStrings, ", "), nil
 }
 
-// String returns the information about the status of a CR as a string to be printed as output
-func (crStatus *CRStatus) String() string {
-	if crStatus.Error != nil {
-		return crStatus.Error.Error()
+// withSpecDrift compares the DNS Names and whether a CA certificate is requested of the Certificate with those
+// of the x509 certificate in the Secret, which differ if the Certificate was edited and has not been reissued yet,
+// or if the issuer ignored spec.isCA. No-op if the Secret could not be parsed.
+func (status *CertificateStatus) withSpecDrift() *CertificateStatus {
+	if status.SecretStatus == nil || status.SecretStatus.Error != nil {
+		return status
 	}
+	status.MissingDNSNames = dnsNamesDifference(status.DNSNames, status.SecretStatus.DNSNames)
+	status.ExtraDNSNames = dnsNamesDifference(status.SecretStatus.DNSNames, status.DNSNames)
+	matches := len(status.MissingDNSNames) == 0 && len(status.ExtraDNSNames) == 0
+	status.SpecMatchesIssued = &matches
+	status.IssuedNotCA = status.IsCA && !status.SecretStatus.IsCA
+	return status
+}
 
-	crFormat := `
-  Name: %s
-  Namespace: %s
-  Conditions:
-  %s`
-	conditionMsg := ""
-	for _, con := range crStatus.Conditions {
-		conditionMsg += fmt.Sprintf("  %s: %s, Reason: %s, Message: %s\n", con.Type, con.Status, con.Reason, con.Message)
+// withDNSNameSources sets the DNS Names requested by the current CertificateRequest and those of the x509 certificate
+// in the Secret, so that the DNS Names of the spec are not mistaken for the DNS Names that were issued.
+// Each is left nil if its source is not known, and is non-nil but empty if the source has no DNS Names.
+func (status *CertificateStatus) withDNSNameSources() *CertificateStatus {
+	if crStatus := status.CRStatus; crStatus != nil && crStatus.Error == nil && crStatus.Requested != nil && crStatus.Requested.Error == nil {
+		status.RequestedDNSNames = append([]string{}, crStatus.Requested.DNSNames...)
 	}
-	if conditionMsg == "" {
-		conditionMsg = "  No Conditions set\n"
+	if secretStatus := status.SecretStatus; secretStatus != nil && secretStatus.Error == nil {
+		status.IssuedDNSNames = append([]string{}, secretStatus.DNSNames...)
 	}
-	infos := fmt.Sprintf(crFormat, crStatus.Name, crStatus.Namespace, conditionMsg)
-	infos = fmt.Sprintf("CertificateRequest:%s", infos)
+	return status
+}
 
-	infos += eventsToString(crStatus.Events, 1)
-	return infos
+// dnsNameSource is a list of DNS Names of the Certificate, labeled by where it comes from
+type dnsNameSource struct {
+	label    string
+	dnsNames []string
 }
 
-// String returns the information about the status of a CR as a string to be printed as output
-func (orderStatus *OrderStatus) String() string {
-	if orderStatus.Error != nil {
-		return orderStatus.Error.Error()
+// writeDNSNames writes the DNS Names of the spec, of the current CertificateRequest and of the x509 certificate in the Secret,
+// each labeled by its source. If they all agree, ignoring order and case, a single list is written.
+// Sources that are not known are left out.
+func (status *CertificateStatus) writeDNSNames(sw *statusWriter) {
+	sources := []dnsNameSource{{label: "spec", dnsNames: status.DNSNames}}
+	if status.RequestedDNSNames != nil {
+		sources = append(sources, dnsNameSource{label: "requested", dnsNames: status.RequestedDNSNames})
+	}
+	if status.IssuedDNSNames != nil {
+		sources = append(sources, dnsNameSource{label: "issued", dnsNames: status.IssuedDNSNames})
 	}
 
-	output := "Order:\n"
-	output += fmt.Sprintf("  Name: %s\n", orderStatus.Name)
-	output += fmt.Sprintf("  State: %s, Reason: %s\n", orderStatus.State, orderStatus.Reason)
-	authString := ""
-	for _, auth := range orderStatus.Authorizations {
-		wildcardString := "nil (bool pointer not set)"
-		if auth.Wildcard != nil {
-			wildcardString = fmt.Sprintf("%t", *auth.Wildcard)
+	matching := true
+	for _, source := range sources[1:] {
+		if len(dnsNamesDifference(status.DNSNames, source.dnsNames)) > 0 || len(dnsNamesDifference(source.dnsNames, status.DNSNames)) > 0 {
+			matching = false
 		}
-		authString += fmt.Sprintf("    URL: %s, Identifier: %s, Initial State: %s, Wildcard: %s\n", auth.URL, auth.Identifier, auth.InitialState, wildcardString)
 	}
-	if authString == "" {
-		output += "  No Authorizations for this Order\n"
-	} else {
-		output += "  Authorizations:\n"
-		output += authString
+	if matching && len(sources) > 1 {
+		sources = []dnsNameSource{{label: "matching", dnsNames: status.DNSNames}}
 	}
-	if orderStatus.FailureTime != nil {
-		output += fmt.Sprintf("  FailureTime: %s\n", formatTimeString(orderStatus.FailureTime))
+	for _, source := range sources {
+		if len(source.dnsNames) == 0 {
+			fmt.Fprintf(sw, "DNS Names (%s): <none>\n", source.label)
+			continue
+		}
+		fmt.Fprintf(sw, "DNS Names (%s):\n%s", source.label, formatStringSlice(source.dnsNames))
 	}
+}
 
-	return output
+// dnsNamesDifference returns the DNS Names of a that are not in b, compared case-insensitively
+func dnsNamesDifference(a, b []string) []string {
+	inB := map[string]bool{}
+	for _, name := range b {
+		inB[strings.ToLower(name)] = true
+	}
+	var difference []string
+	for _, name := range a {
+		if !inB[strings.ToLower(name)] {
+			difference = append(difference, name)
+		}
+	}
+	return difference
 }
 
-func (c *ChallengeStatusList) String() string {
-	if c.Error != nil {
-		return c.Error.Error()
+// withSignaturePolicy assesses the signature algorithm of the x509 certificate in the Secret
+// against minStrength, one of signatureStrengthTiers. No-op if minStrength is empty
+// or the Secret could not be parsed.
+func (status *CertificateStatus) withSignaturePolicy(minStrength string) *CertificateStatus {
+	if minStrength == "" || status.SecretStatus == nil || status.SecretStatus.Error != nil {
+		return status
 	}
+	strength := signatureStrength(status.SecretStatus.SignatureAlgorithm)
+	rank := signatureStrengthRank(strength)
+	status.SecretStatus.SignaturePolicy = &SignaturePolicyStatus{Strength: strength, MinStrength: minStrength,
+		Compliant: rank >= 0 && rank >= signatureStrengthRank(minStrength)}
+	return status
+}
 
-	challengeStrings := []string{}
-	for _, challengeStatus := range c.ChallengeStatuses {
-		challengeStrings = append(challengeStrings, challengeStatus.String())
+// withRequiredPolicies checks that the Certificate Policies of the x509 certificate in the Secret include
+// each of the required OIDs, given in dotted notation. No-op if none are required or the Secret could not be parsed
+func (status *CertificateStatus) withRequiredPolicies(required []string) *CertificateStatus {
+	if len(required) == 0 || status.SecretStatus == nil || status.SecretStatus.Error != nil {
+		return status
 	}
-	output := "Challenges:\n"
-	output += formatStringSlice(challengeStrings)
-	return output
+	present := map[string]bool{}
+	for _, oid := range oidsToStrings(status.SecretStatus.PolicyIdentifiers) {
+		present[oid] = true
+	}
+	for _, oid := range required {
+		if !present[oid] {
+			status.SecretStatus.MissingPolicies = append(status.SecretStatus.MissingPolicies, oid)
+		}
+	}
+	return status
 }
 
-func (challengeStatus *ChallengeStatus) String() string {
-	return fmt.Sprintf("Name: %s, Type: %s, Token: %s, Key: %s, State: %s, Reason: %s, Processing: %t, Presented: %t",
-		challengeStatus.Name, challengeStatus.Type, challengeStatus.Token, challengeStatus.Key, challengeStatus.State,
-		challengeStatus.Reason, challengeStatus.Processing, challengeStatus.Presented)
+// parseOID parses an OID in dotted notation, e.g. 2.23.140.1.2.1
+func parseOID(s string) (asn1.ObjectIdentifier, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("%q is not an OID in dotted notation", s)
+	}
+	oid := make(asn1.ObjectIdentifier, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%q is not an OID in dotted notation", s)
+		}
+		oid[i] = n
+	}
+	return oid, nil
+}
+
+// withDERSerialNumber sets whether the serial number of the x509 certificate in the Secret
+// is printed as the content of its DER INTEGER encoding
+func (status *CertificateStatus) withDERSerialNumber(der bool) *CertificateStatus {
+	if status.SecretStatus != nil {
+		status.SecretStatus.SerialNumberDER = der
+	}
+	return status
+}
+
+// withRawX509 sets whether every field and extension of the x509 certificate in the Secret is printed
+func (status *CertificateStatus) withRawX509(raw bool) *CertificateStatus {
+	if status.SecretStatus != nil {
+		status.SecretStatus.RawX509 = raw
+	}
+	return status
+}
+
+// withFingerprintAlgorithm sets the hash algorithm of the fingerprint of the x509 certificate
+// in the Secret that is printed, one of fingerprintAlgorithms
+func (status *CertificateStatus) withFingerprintAlgorithm(algorithm string) *CertificateStatus {
+	if status.SecretStatus != nil {
+		status.SecretStatus.FingerprintAlgorithm = algorithm
+	}
+	return status
+}
+
+// newCAValidityStatus compares the validity of cert with the validity of the CA certificate that issued it.
+// The CA certificate is taken from the certificate chain in 'tls.crt' of secret, or else from 'ca.crt'.
+// Returns nil if secret holds no CA certificate.
+func newCAValidityStatus(cert *x509.Certificate, secret *v1.Secret) *CAValidityStatus {
+	var caCert *x509.Certificate
+	source := ""
+	if chain, err := pki.DecodeX509CertificateChainBytes(secret.Data["tls.crt"]); err == nil && len(chain) > 1 {
+		caCert, source = chain[1], "tls.crt"
+	} else if caData := secret.Data["ca.crt"]; len(caData) > 0 {
+		if caCert, err = pki.DecodeX509CertificateBytes(caData); err == nil {
+			source = "ca.crt"
+		}
+	}
+	if source == "" {
+		return nil
+	}
+
+	trustedUntil := cert.NotAfter
+	if caCert.NotAfter.Before(trustedUntil) {
+		trustedUntil = caCert.NotAfter
+	}
+	return &CAValidityStatus{Source: source, NotBefore: caCert.NotBefore, NotAfter: caCert.NotAfter,
+		NotBeforePrecedesCA: cert.NotBefore.Before(caCert.NotBefore),
+		NotAfterExceedsCA:   cert.NotAfter.After(caCert.NotAfter),
+		TrustedUntil:        trustedUntil}
+}
+
+// newChainStatus returns the subject and issuer of each certificate of chain, and whether chain is out of order,
+// that is the issuer of a certificate is not the subject of the certificate following it.
+// Returns nil if chain holds a single certificate.
+func newChainStatus(chain []*x509.Certificate) ([]ChainCertificateStatus, bool) {
+	if len(chain) < 2 {
+		return nil, false
+	}
+	var statuses []ChainCertificateStatus
+	outOfOrder := false
+	for i, cert := range chain {
+		statuses = append(statuses, ChainCertificateStatus{Subject: cert.Subject.String(), Issuer: cert.Issuer.String()})
+		if i+1 < len(chain) && !bytes.Equal(cert.RawIssuer, chain[i+1].RawSubject) {
+			outOfOrder = true
+		}
+	}
+	return statuses, outOfOrder
+}
+
+// Data keys of the Secret that cert-manager stores the keystores of a Certificate in
+const (
+	pkcs12SecretKey = "keystore.p12"
+	jksSecretKey    = "keystore.jks"
+)
+
+// newKeystoresStatus returns the sizes of the keystores in secret, or nil if it holds none
+func newKeystoresStatus(secret *v1.Secret) *KeystoresStatus {
+	pkcs12Size, jksSize := len(secret.Data[pkcs12SecretKey]), len(secret.Data[jksSecretKey])
+	if pkcs12Size == 0 && jksSize == 0 {
+		return nil
+	}
+	return &KeystoresStatus{PKCS12Size: pkcs12Size, JKSSize: jksSize}
+}
+
+// withPKCS12Verification decodes 'keystore.p12' of secret with password and checks that its certificate
+// is the x509 certificate in 'tls.crt'. No-op if password is empty or the Secret holds no PKCS#12 keystore.
+func (status *CertificateStatus) withPKCS12Verification(secret *v1.Secret, password string) *CertificateStatus {
+	if password == "" || secret == nil || status.SecretStatus == nil || status.SecretStatus.Error != nil {
+		return status
+	}
+	keystores := status.SecretStatus.Keystores
+	if keystores == nil || keystores.PKCS12Size == 0 {
+		return status
+	}
+	_, keystoreCert, err := pkcs12.Decode(secret.Data[pkcs12SecretKey], password)
+	if err != nil {
+		keystores.PKCS12Error = err.Error()
+		return status
+	}
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data["tls.crt"])
+	if err != nil {
+		return status
+	}
+	matches := keystoreCert.Equal(cert)
+	keystores.PKCS12MatchesCert = &matches
+	return status
+}
+
+// withChainVerification verifies that the x509 certificate in the Secret, with the intermediates following it
+// in 'tls.crt', chains to a CA certificate in 'ca.crt' of secret, or to a root trusted by the host if systemRoots
+// is true. No-op if there is nothing to verify against or the Secret could not be parsed.
+func (status *CertificateStatus) withChainVerification(secret *v1.Secret, systemRoots bool, clock clock.Clock) *CertificateStatus {
+	if secret == nil || status.SecretStatus == nil || status.SecretStatus.Error != nil {
+		return status
+	}
+	chain, err := pki.DecodeX509CertificateChainBytes(secret.Data["tls.crt"])
+	if err != nil {
+		return status
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	var rootPools []*x509.CertPool
+	if caData := secret.Data["ca.crt"]; len(caData) > 0 {
+		caCerts, err := pki.DecodeX509CertificateChainBytes(caData)
+		if err != nil {
+			status.SecretStatus.ChainError = fmt.Sprintf("error when parsing 'ca.crt' of Secret %q: %s", secret.Name, err)
+			return status
+		}
+		caPool := x509.NewCertPool()
+		for _, cert := range caCerts {
+			caPool.AddCert(cert)
+			// A CA certificate in 'ca.crt' may be an intermediate of a root trusted by the host
+			intermediates.AddCert(cert)
+		}
+		rootPools = append(rootPools, caPool)
+	}
+	if systemRoots {
+		systemPool, err := x509.SystemCertPool()
+		if err != nil {
+			status.SecretStatus.ChainError = fmt.Sprintf("error when loading the roots trusted by the host: %s", err)
+			return status
+		}
+		rootPools = append(rootPools, systemPool)
+	}
+
+	var verifyErr error
+	for _, roots := range rootPools {
+		chains, err := chain[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates,
+			CurrentTime: clock.Now(), KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}})
+		if err == nil {
+			status.SecretStatus.ChainVerified, status.SecretStatus.ChainLength = true, len(chains[0])
+			return status
+		}
+		// Report why verification against 'ca.crt' failed, which is tried first
+		if verifyErr == nil {
+			verifyErr = err
+		}
+	}
+	if verifyErr != nil {
+		status.SecretStatus.ChainError = verifyErr.Error()
+	}
+	return status
+}
+
+// weakSignatureAlgorithm returns true if the signature algorithm of the x509 certificate in the Secret
+// is deprecated as weak, unless that is already reported as a violation of the signature policy
+func (secretStatus *SecretStatus) weakSignatureAlgorithm() bool {
+	if policy := secretStatus.SignaturePolicy; policy != nil && !policy.Compliant {
+		return false
+	}
+	return isWeakSignatureAlgorithm(secretStatus.SignatureAlgorithm)
+}
+
+// minRSAKeyBits is the size of RSA keys below which a warning is printed
+const minRSAKeyBits = 2048
+
+// publicKeySize returns the size in bits and the curve, if any, of publicKey
+func publicKeySize(publicKey crypto.PublicKey) (int, string) {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return key.N.BitLen(), ""
+	case *ecdsa.PublicKey:
+		return key.Curve.Params().BitSize, key.Curve.Params().Name
+	case ed25519.PublicKey:
+		// Ed25519 keys have a single fixed size and no choice of curve, which the algorithm name already says
+		return 0, ""
+	}
+	return 0, ""
+}
+
+// keyMatchesCert returns whether the private key in 'tls.key' of secret matches the public key of cert.
+// Returns nil if 'tls.key' is not set, and an error as well if it could not be parsed.
+func keyMatchesCert(secret *v1.Secret, cert *x509.Certificate) (*bool, error) {
+	keyData := secret.Data["tls.key"]
+	if len(keyData) == 0 {
+		return nil, nil
+	}
+	key, err := pki.DecodePrivateKeyBytes(keyData)
+	if err != nil {
+		return nil, fmt.Errorf("error when parsing 'tls.key' of Secret %q: %s", secret.Name, err)
+	}
+	// The RSA, ECDSA and Ed25519 public keys all implement Equal
+	publicKey, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool })
+	matches := ok && publicKey.Equal(cert.PublicKey)
+	return &matches, nil
+}
+
+// newCACertificateStatus returns the status of the CA certificate in 'ca.crt' of secret.
+// Returns nil if 'ca.crt' is not set, as many issuers do not set it.
+func newCACertificateStatus(secret *v1.Secret) *CACertificateStatus {
+	caData := secret.Data["ca.crt"]
+	if len(caData) == 0 {
+		return nil
+	}
+	caCert, err := pki.DecodeX509CertificateBytes(caData)
+	if err != nil {
+		return &CACertificateStatus{Error: fmt.Errorf("error when parsing 'ca.crt' of Secret %q: %s\n", secret.Name, err)}
+	}
+	return &CACertificateStatus{SubjectCommonName: caCert.Subject.CommonName, IssuerCommonName: caCert.Issuer.CommonName,
+		NotAfter: caCert.NotAfter, SerialNumber: caCert.SerialNumber}
+}
+
+func (status *CertificateStatus) withCR(req *cmapi.CertificateRequest, events *v1.EventList, err error, clock clock.Clock) *CertificateStatus {
+	status.CRStatus = NewCRStatus(req, events, err, clock)
+	return status
+}
+
+// NewCRStatus returns the CRStatus of req with its events, observed at the current time of clock.
+// If err is not nil, the returned CRStatus only carries err. If both req and err are nil, nil is returned.
+func NewCRStatus(req *cmapi.CertificateRequest, events *v1.EventList, err error, clock clock.Clock) *CRStatus {
+	if err != nil {
+		return &CRStatus{Error: err}
+	}
+	if req == nil {
+		return nil
+	}
+	crStatus := &CRStatus{Name: req.Name, Namespace: req.Namespace, Conditions: req.Status.Conditions,
+		Requested: newRequestedStatus(req), Events: events, ObservedAt: clock.Now()}
+	crStatus.Revision, _ = strconv.Atoi(req.Annotations[cmapi.CertificateRequestRevisionAnnotationKey])
+	if !req.CreationTimestamp.IsZero() {
+		creationTime := req.CreationTimestamp
+		crStatus.CreationTime = &creationTime
+	}
+	return crStatus
+}
+
+// withCRHistory sets the statuses of reqs, the CertificateRequests owned by the Certificate, with their events if known
+func (status *CertificateStatus) withCRHistory(reqs []*cmapi.CertificateRequest, events map[string]*v1.EventList, clock clock.Clock) *CertificateStatus {
+	for _, req := range reqs {
+		crStatus := NewCRStatus(req, events[req.Name], nil, clock)
+		crStatus.Age = clock.Since(req.CreationTimestamp.Time)
+		status.CRHistory = append(status.CRHistory, crStatus)
+	}
+	return status
+}
+
+// withCRHistoryDetails sets whether the details and events of each CertificateRequest in the history are printed
+func (status *CertificateStatus) withCRHistoryDetails(details bool) *CertificateStatus {
+	status.CRHistoryDetails = details
+	return status
+}
+
+// withEventsShown sets whether the events of the Certificate and its related resources are printed,
+// which are not looked up at all if they are not
+func (status *CertificateStatus) withEventsShown(show bool) *CertificateStatus {
+	status.HideEvents = !show
+	if status.IssuerStatus != nil {
+		status.IssuerStatus.HideEvents = !show
+	}
+	if status.SecretStatus != nil {
+		status.SecretStatus.HideEvents = !show
+	}
+	if status.CRStatus != nil {
+		status.CRStatus.HideEvents = !show
+	}
+	for _, crStatus := range status.CRHistory {
+		crStatus.HideEvents = !show
+	}
+	return status
+}
+
+// newRequestedStatus returns what was requested by req, decoded from its certificate signing request.
+// The usages and isCA are taken from the spec of req, since they are what the issuers sign the certificate with.
+// Returns nil if req has no certificate signing request.
+func newRequestedStatus(req *cmapi.CertificateRequest) *RequestedStatus {
+	if len(req.Spec.Request) == 0 {
+		return nil
+	}
+	csr, err := pki.DecodeX509CertificateRequestBytes(req.Spec.Request)
+	if err != nil {
+		return &RequestedStatus{Error: fmt.Errorf("error when decoding the certificate signing request: %s\n", err)}
+	}
+	return &RequestedStatus{
+		CommonName:     csr.Subject.CommonName,
+		DNSNames:       csr.DNSNames,
+		IPAddresses:    pki.IPAddressesToString(csr.IPAddresses),
+		URIs:           pki.URLsToString(csr.URIs),
+		EmailAddresses: csr.EmailAddresses,
+		Usages:         req.Spec.Usages,
+		IsCA:           req.Spec.IsCA,
+	}
+}
+
+func (status *CertificateStatus) withOrder(order *cmacme.Order, err error) *CertificateStatus {
+	status.OrderStatus = NewOrderStatus(order, err)
+	return status
+}
+
+// NewOrderStatus returns the OrderStatus of order.
+// If err is not nil, the returned OrderStatus only carries err. If both order and err are nil, nil is returned.
+func NewOrderStatus(order *cmacme.Order, err error) *OrderStatus {
+	if err != nil {
+		return &OrderStatus{Error: err}
+	}
+	if order == nil {
+		return nil
+	}
+	return &OrderStatus{Name: order.Name, State: order.Status.State,
+		Reason: order.Status.Reason, Authorizations: order.Status.Authorizations,
+		FailureTime: order.Status.FailureTime}
+}
+
+func (status *CertificateStatus) withChallenges(challenges []*cmacme.Challenge, err error) *CertificateStatus {
+	if err != nil {
+		status.ChallengeStatusList = &ChallengeStatusList{Error: err}
+		return status
+	}
+	if len(challenges) == 0 {
+		return status
+	}
+
+	var list []*ChallengeStatus
+	for _, challenge := range challenges {
+		list = append(list, &ChallengeStatus{
+			Name:       challenge.Name,
+			Type:       challenge.Spec.Type,
+			DNSName:    challenge.Spec.DNSName,
+			Token:      challenge.Spec.Token,
+			Key:        challenge.Spec.Key,
+			State:      challenge.Status.State,
+			Reason:     challenge.Status.Reason,
+			Processing: challenge.Status.Processing,
+			Presented:  challenge.Status.Presented,
+		})
+	}
+	status.ChallengeStatusList = &ChallengeStatusList{ChallengeStatuses: list}
+	return status
+}
+
+// withDNSChecks attaches to each challenge the lookups of its TXT record, keyed by challenge name
+func (status *CertificateStatus) withDNSChecks(lookups map[string][]DNSLookup) *CertificateStatus {
+	if len(lookups) == 0 || status.ChallengeStatusList == nil {
+		return status
+	}
+	for _, challengeStatus := range status.ChallengeStatusList.ChallengeStatuses {
+		if challengeLookups, ok := lookups[challengeStatus.Name]; ok {
+			challengeStatus.DNSCheck = &DNSCheckStatus{FQDN: dns01ChallengeFQDN(challengeStatus.DNSName),
+				Expected: challengeStatus.Key, Lookups: challengeLookups}
+		}
+	}
+	return status
+}
+
+// WriteTo writes the information about the status of the Certificate and its related resources to w
+// to be printed as output. The event tables of all resources are aligned by a single tabwriter.
+func (status *CertificateStatus) WriteTo(w io.Writer) (int64, error) {
+	return withTabWriter(w, func(sw *statusWriter) {
+		fmt.Fprintf(sw, "%s\n", status.Summary())
+		fmt.Fprintf(sw, "Name: %s\n", status.Name)
+		fmt.Fprintf(sw, "Namespace: %s\n", status.Namespace)
+		fmt.Fprintf(sw, "Created at: %s\n", formatCreatedAt(status.CreationTime, status.ObservedAt))
+		if status.Revision != nil || status.Issuing {
+			fmt.Fprintf(sw, "Revision: %s\n", formatRevision(status.Revision, status.Issuing))
+		}
+		fmt.Fprintf(sw, "Last Issued: %s\n", status.formatLastIssued())
+		status.writeRenewalState(sw)
+
+		// Output one line about each type of Condition that is set.
+		// Certificate can have multiple Conditions of different types set, e.g. "Ready" or "Issuing"
+		fmt.Fprint(sw, "Conditions:\n")
+		writeCertificateConditions(sw, status.Conditions)
+
+		status.writeDNSNames(sw)
+		if len(status.MissingDNSNames) > 0 {
+			fmt.Fprintf(sw, "  Missing from the certificate: %s\n", strings.Join(status.MissingDNSNames, ", "))
+		}
+		if len(status.ExtraDNSNames) > 0 {
+			fmt.Fprintf(sw, "  Not in the spec: %s\n", strings.Join(status.ExtraDNSNames, ", "))
+		}
+
+		if !status.HideEvents {
+			writeEvents(sw, status.Events, status.SuppressedEvents, 0, status.ObservedAt)
+		}
+
+		status.IssuerStatus.WriteTo(sw)
+		status.SecretStatus.WriteTo(sw)
+
+		if status.ProbeStatus != nil {
+			fmt.Fprint(sw, status.ProbeStatus.String())
+		}
+
+		// The next private key only exists while the Certificate is being reissued
+		if status.Issuing && status.NextPrivateKeyStatus != nil {
+			fmt.Fprint(sw, status.NextPrivateKeyStatus.String())
+		}
+
+		status.writeValidity(sw)
+
+		status.CRStatus.WriteTo(sw)
+
+		// OrderStatus is nil is not found or Issuer/ClusterIssuer is not ACME Issuer
+		if status.OrderStatus != nil {
+			fmt.Fprint(sw, status.OrderStatus.String())
+		}
+
+		if status.ChallengeStatusList != nil {
+			fmt.Fprint(sw, status.ChallengeStatusList.String())
+		}
+
+		status.writeCRHistory(sw)
+
+		status.writeWarnings(sw)
+	})
+}
+
+// formatCreatedAt formats the creation time of a resource with its age at now.
+// The age is omitted if now or the creation time is not known
+func formatCreatedAt(creationTime metav1.Time, now time.Time) string {
+	if now.IsZero() || creationTime.IsZero() {
+		return formatTimeString(&creationTime)
+	}
+	return fmt.Sprintf("%s (%s ago)", formatTimeString(&creationTime), duration.HumanDuration(now.Sub(creationTime.Time)))
+}
+
+// formatLastIssued formats when the certificate of the current revision of the Certificate was issued,
+// taken from the Not Before of the certificate in the Secret
+func (status *CertificateStatus) formatLastIssued() string {
+	secretStatus := status.SecretStatus
+	switch {
+	case status.Revision == nil:
+		return "never, no revision of the Certificate has been issued yet"
+	case secretStatus == nil || secretStatus.Error != nil:
+		return fmt.Sprintf("unknown, revision %d was issued but the certificate in the Secret could not be read", *status.Revision)
+	case status.ObservedAt.IsZero():
+		return fmt.Sprintf("%s (revision %d)", secretStatus.NotBefore.Format(time.RFC3339), *status.Revision)
+	default:
+		return fmt.Sprintf("%s (revision %d, %s)", formatRelativeDuration(secretStatus.NotBefore.Sub(status.ObservedAt)),
+			*status.Revision, secretStatus.NotBefore.Format(time.RFC3339))
+	}
+}
+
+// formatRevision formats the revision of a Certificate, noting whether it is being issued for the first time
+// or reissued
+func formatRevision(revision *int, issuing bool) string {
+	switch {
+	case revision == nil && issuing:
+		return "<none> (issuance in progress)"
+	case revision == nil:
+		return "<none>"
+	case issuing:
+		return fmt.Sprintf("%d (reissuance in progress)", *revision)
+	default:
+		return strconv.Itoa(*revision)
+	}
+}
+
+func (status *CertificateStatus) String() string {
+	return writerToString(status)
+}
+
+// writeCertificateConditions writes one line about each Condition of a Certificate
+func writeCertificateConditions(sw *statusWriter, conditions []cmapi.CertificateCondition) {
+	if len(conditions) == 0 {
+		fmt.Fprint(sw, "  No Conditions set\n")
+	}
+	for _, con := range sortedCertificateConditions(conditions) {
+		fmt.Fprintf(sw, "  %s, Reason: %s, Message: %s\n", sw.conditionStatus(string(con.Type), con.Status), con.Reason, con.Message)
+	}
+}
+
+// Summary returns a single line with the name, namespace and readiness of the Certificate,
+// when its certificate expires and the name of its Issuer, e.g.
+// "web-tls (prod): Ready, expires in 29d via letsencrypt-prod".
+// The expiry is unknown if the Secret could not be read, and the Issuer is left out if it could not be found.
+func (status *CertificateStatus) Summary() string {
+	ready := "Ready unknown"
+	switch isReady, ok := status.IsReady(); {
+	case ok && isReady:
+		ready = "Ready"
+	case ok:
+		ready = "Not Ready"
+	}
+
+	expiry := "expiry unknown"
+	if secretStatus := status.SecretStatus; secretStatus != nil && secretStatus.Error == nil {
+		if secretStatus.Expired {
+			expiry = fmt.Sprintf("expired %s ago", duration.HumanDuration(-secretStatus.ExpiresIn))
+		} else {
+			expiry = fmt.Sprintf("expires in %s", duration.HumanDuration(secretStatus.ExpiresIn))
+		}
+	}
+
+	summary := fmt.Sprintf("%s (%s): %s, %s", status.Name, status.Namespace, ready, expiry)
+	if issuerStatus := status.IssuerStatus; issuerStatus != nil && issuerStatus.Error == nil {
+		summary += " via " + issuerStatus.Name
+	}
+	return summary
+}
+
+// CompactString returns the most important facts about the status of the Certificate
+// in a single compact block to be printed as output
+func (status *CertificateStatus) CompactString() string {
+	output := fmt.Sprintf("Certificate: %s/%s\n", status.Namespace, status.Name)
+
+	ready := "Unknown, no Ready condition set"
+	for _, con := range status.Conditions {
+		if con.Type == cmapi.CertificateConditionReady {
+			ready = fmt.Sprintf("%s, Reason: %s, Message: %s", con.Status, con.Reason, con.Message)
+		}
+	}
+	output += fmt.Sprintf("Ready: %s\n", ready)
+
+	switch {
+	case status.IssuerStatus == nil:
+		output += "Issuer: <none>\n"
+	case status.IssuerStatus.Error != nil:
+		output += fmt.Sprintf("Issuer: %s\n", strings.TrimSpace(status.IssuerStatus.Error.Error()))
+	case status.IssuerStatus.External:
+		output += fmt.Sprintf("Issuer: %s (%s.%s, external)\n", status.IssuerStatus.Name, status.IssuerStatus.Kind, status.IssuerStatus.Group)
+	default:
+		output += fmt.Sprintf("Issuer: %s (%s)\n", status.IssuerStatus.Name, status.IssuerStatus.Kind)
+	}
+
+	output += fmt.Sprintf("DNS Names: %d\n", len(status.DNSNames))
+
+	var expiresIn, renewalIn *DurationStatus
+	if status.Validity != nil {
+		expiresIn, renewalIn = status.Validity.ExpiresIn, status.Validity.RenewalIn
+	}
+	output += fmt.Sprintf("Not After: %s\n", formatTimeWithDuration(status.NotAfter, expiresIn))
+	output += fmt.Sprintf("Renewal Time: %s\n", formatTimeWithDuration(status.RenewalTime, renewalIn))
+
+	output += fmt.Sprintf("Warnings: %d\n", len(status.warnings()))
+	return output
+}
+
+// exitCode returns the exit code of the command for --exit-code and the reason for it,
+// or 0 if the Certificate is healthy
+func (status *CertificateStatus) exitCode() (int, string) {
+	switch {
+	case status.IssuerStatus != nil && status.IssuerStatus.Error != nil:
+		return exitCodeLookupError, "error when getting the Issuer of the Certificate"
+	case status.SecretStatus != nil && status.SecretStatus.Error != nil && !apierrors.IsNotFound(status.SecretStatus.Error):
+		return exitCodeLookupError, "error when getting the Secret of the Certificate"
+	case status.CRStatus != nil && status.CRStatus.Error != nil && !errors.Is(status.CRStatus.Error, errNoCertificateRequest):
+		return exitCodeLookupError, "error when getting the CertificateRequest of the Certificate"
+	}
+
+	if ready, _ := status.IsReady(); !ready {
+		return exitCodeNotReady, "the Certificate is not Ready"
+	}
+
+	if status.SecretStatus != nil && status.SecretStatus.SignaturePolicy != nil && !status.SecretStatus.SignaturePolicy.Compliant {
+		return exitCodeSignaturePolicy, "the signature algorithm of the certificate is below the minimum strength required by policy"
+	}
+
+	if highestSeverity(status.Warnings()) == WarningSeverityError {
+		return exitCodeWarningError, "a problem with the certificate in the Secret stops it from being used"
+	}
+	return 0, ""
+}
+
+// ConditionsMap returns the status of each Condition of the Certificate by its type, e.g. "Ready": "True",
+// for scripts that only need to know whether a Condition holds. Returns nil if no Conditions are set.
+func (status *CertificateStatus) ConditionsMap() map[string]string {
+	if len(status.Conditions) == 0 {
+		return nil
+	}
+	conditions := make(map[string]string, len(status.Conditions))
+	for _, con := range status.Conditions {
+		conditions[string(con.Type)] = string(con.Status)
+	}
+	return conditions
+}
+
+// IsReady returns whether the Ready condition of the Certificate is True.
+// ok is false if the Ready condition is not set or its status is Unknown.
+func (status *CertificateStatus) IsReady() (ready bool, ok bool) {
+	for _, con := range status.Conditions {
+		if con.Type == cmapi.CertificateConditionReady && con.Status != cmmeta.ConditionUnknown {
+			return con.Status == cmmeta.ConditionTrue, true
+		}
+	}
+	return false, false
+}
+
+// secretExpired returns true if the certificate in the Secret could be read and has expired
+func (status *CertificateStatus) secretExpired() bool {
+	return status.SecretStatus != nil && status.SecretStatus.Error == nil && status.SecretStatus.Expired
+}
+
+// WarningSeverity is how serious a Warning about a Certificate is
+type WarningSeverity string
+
+const (
+	// WarningSeverityInfo is used for something that may be intended but is worth knowing about
+	WarningSeverityInfo WarningSeverity = "info"
+	// WarningSeverityWarning is used for a problem that does not yet stop the certificate from being used
+	WarningSeverityWarning WarningSeverity = "warning"
+	// WarningSeverityError is used for a problem that stops the certificate in the Secret from being used
+	WarningSeverityError WarningSeverity = "error"
+)
+
+// rank orders the severities from info to error, for finding the highest severity of a list of Warnings
+func (severity WarningSeverity) rank() int {
+	switch severity {
+	case WarningSeverityInfo:
+		return 1
+	case WarningSeverityWarning:
+		return 2
+	case WarningSeverityError:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// Warning is a problem detected with a Certificate or its related resources
+type Warning struct {
+	// Severity of the problem, one of info, warning or error
+	Severity WarningSeverity `json:"severity"`
+	// Code identifies the check that detected the problem, e.g. "CertificateExpired", for scripts to match on
+	Code string `json:"code"`
+	// Message describes the problem
+	Message string `json:"message"`
+}
+
+// Warnings returns a Warning for each problem detected with the Certificate or its related resources.
+// Every check of the status is listed here, so that the text, JSON and YAML output all report the same problems.
+func (status *CertificateStatus) Warnings() []Warning {
+	var warnings []Warning
+	add := func(severity WarningSeverity, code, message string) {
+		warnings = append(warnings, Warning{Severity: severity, Code: code, Message: message})
+	}
+	if validity := status.Validity; validity != nil {
+		if validity.RenewalAfterExpiry {
+			add(WarningSeverityWarning, "RenewalAfterExpiry", "the Renewal Time is after Not After")
+		}
+		// An Overdue renewal state is reported below in place of the Renewal Time having passed
+		if validity.RenewalOverdue && status.RenewalState != renewalStateOverdue {
+			add(WarningSeverityWarning, "RenewalOverdue", "the Renewal Time has passed")
+		}
+	}
+	// An expired certificate in the Secret is already reported as CertificateExpired
+	if status.RenewalState == renewalStateOverdue && !status.secretExpired() {
+		add(WarningSeverityWarning, "RenewalStateOverdue", "the Certificate should have been renewed but is not being reissued or its certificate has expired")
+	}
+	if issuerStatus := status.IssuerStatus; issuerStatus != nil && issuerStatus.Error == nil {
+		if caStatus := issuerStatus.CAStatus; caStatus != nil && caStatus.Error == nil && caStatus.ExpiresIn < issuerCANearExpiryThreshold {
+			add(WarningSeverityWarning, "IssuerCANearExpiry", "the CA certificate of the Issuer has expired or is near expiry")
+		}
+	}
+	if status.SpecMatchesIssued != nil && !*status.SpecMatchesIssued {
+		add(WarningSeverityWarning, "DNSNamesMismatch", "the DNS Names differ from those of the certificate in the Secret")
+	}
+	if status.IssuedNotCA {
+		add(WarningSeverityWarning, "IssuedNotCA", "spec.isCA is set but the certificate in the Secret is not a CA certificate")
+	}
+	if status.DurationShortened {
+		add(WarningSeverityInfo, "DurationShortened", "the issuer shortened the duration of the certificate")
+	}
+	if len(status.SecretContention) > 0 {
+		add(WarningSeverityWarning, "SecretContention", "Secret contention with: "+strings.Join(status.SecretContention, ", "))
+	}
+	if status.Issuing && status.NextPrivateKeyStatus != nil && status.NextPrivateKeyStatus.Error != nil {
+		add(WarningSeverityWarning, "NextPrivateKeyUnreadable", "the next private key could not be read")
+	}
+	if secretStatus := status.SecretStatus; secretStatus != nil && secretStatus.Error == nil {
+		if secretStatus.NotYetValid {
+			add(WarningSeverityError, "CertificateNotYetValid", "the certificate in the Secret is not yet valid")
+		}
+		if secretStatus.Expired {
+			add(WarningSeverityError, "CertificateExpired", "the certificate in the Secret has expired")
+		}
+		if secretStatus.IsPrecertificate {
+			add(WarningSeverityError, "Precertificate", "the certificate is a Certificate Transparency precertificate")
+		}
+		if secretStatus.PublicKeyAlgorithm == x509.RSA && secretStatus.KeyBits > 0 && secretStatus.KeyBits < minRSAKeyBits {
+			add(WarningSeverityWarning, "WeakRSAKey", "the RSA key of the certificate is weak")
+		}
+		if secretStatus.ChainOutOfOrder {
+			add(WarningSeverityWarning, "ChainOutOfOrder", "the certificate chain in the Secret is out of order")
+		}
+		if secretStatus.wrongType() {
+			add(WarningSeverityWarning, "SecretWrongType", "the Secret is not of type kubernetes.io/tls")
+		}
+		if secretStatus.CertificateNameMismatch {
+			add(WarningSeverityWarning, "SecretManagedByOtherCertificate", "the Secret is annotated as managed by another Certificate")
+		}
+		if secretStatus.ChainError != "" {
+			add(WarningSeverityError, "ChainUnverified", "the certificate chain in the Secret could not be verified")
+		}
+		if keystores := secretStatus.Keystores; keystores != nil {
+			if keystores.PKCS12Error != "" {
+				add(WarningSeverityError, "PKCS12Undecodable", "the PKCS#12 keystore in the Secret could not be decoded: "+keystores.PKCS12Error)
+			}
+			if keystores.PKCS12MatchesCert != nil && !*keystores.PKCS12MatchesCert {
+				add(WarningSeverityError, "PKCS12Mismatch", "the PKCS#12 keystore in the Secret does not hold the certificate")
+			}
+		}
+		if secretStatus.KeyError != nil {
+			add(WarningSeverityError, "KeyUnreadable", secretStatus.KeyError.Error())
+		}
+		if secretStatus.KeyMatchesCert != nil && !*secretStatus.KeyMatchesCert {
+			add(WarningSeverityError, "KeyMismatch", "the private key in the Secret does not match the certificate")
+		}
+		if secretStatus.weakSignatureAlgorithm() {
+			add(WarningSeverityWarning, "WeakSignatureAlgorithm", "the signature algorithm is weak and deprecated")
+		}
+		if policy := secretStatus.SignaturePolicy; policy != nil && !policy.Compliant {
+			add(WarningSeverityWarning, "SignaturePolicy", "the signature algorithm is below the minimum strength required by policy")
+		}
+		if len(secretStatus.MissingPolicies) > 0 {
+			add(WarningSeverityWarning, "MissingCertificatePolicies", "the certificate does not have the Certificate Policies required by policy")
+		}
+		if caValidity := secretStatus.CAValidity; caValidity != nil {
+			if caValidity.NotBeforePrecedesCA {
+				add(WarningSeverityWarning, "NotBeforePrecedesCA", "the certificate becomes valid before the CA certificate does")
+			}
+			if caValidity.NotAfterExceedsCA {
+				add(WarningSeverityWarning, "NotAfterExceedsCA", "the certificate expires after the CA certificate")
+			}
+		}
+	}
+	if probeStatus := status.ProbeStatus; probeStatus != nil {
+		if probeStatus.Error != "" {
+			add(WarningSeverityWarning, "ProbeFailed", "the TLS handshake with "+probeStatus.Address+" failed")
+		} else {
+			if probeStatus.MatchesSecret != nil && !*probeStatus.MatchesSecret {
+				add(WarningSeverityError, "ProbeMismatch", probeStatus.Address+" serves a different certificate than the one in the Secret")
+			}
+			if probeStatus.HostnameError != "" {
+				add(WarningSeverityError, "ProbeHostnameInvalid", "the certificate served by "+probeStatus.Address+" is not valid for its host")
+			}
+		}
+	}
+	return warnings
+}
+
+// warnings returns the message of each of the Warnings of the Certificate
+func (status *CertificateStatus) warnings() []string {
+	var messages []string
+	for _, warning := range status.Warnings() {
+		messages = append(messages, warning.Message)
+	}
+	return messages
+}
+
+// highestSeverity returns the highest severity of warnings, or an empty severity if there are none
+func highestSeverity(warnings []Warning) WarningSeverity {
+	var highest WarningSeverity
+	for _, warning := range warnings {
+		if warning.Severity.rank() > highest.rank() {
+			highest = warning.Severity
+		}
+	}
+	return highest
+}
+
+// writeWarnings writes the Warnings of the Certificate in a single section at the end of the status,
+// errors in red. Nothing is written if there are no Warnings.
+func (status *CertificateStatus) writeWarnings(sw *statusWriter) {
+	warnings := status.Warnings()
+	if len(warnings) == 0 {
+		return
+	}
+	fmt.Fprint(sw, "Warnings:\n")
+	for _, warning := range warnings {
+		line := fmt.Sprintf("%s: %s (%s)", warning.Severity, warning.Message, warning.Code)
+		switch warning.Severity {
+		case WarningSeverityError:
+			line = sw.colorize(colorRed, line)
+		case WarningSeverityWarning:
+			line = sw.colorize(colorYellow, line)
+		}
+		fmt.Fprintf(sw, "  %s\n", line)
+	}
+}
+
+// writeValidity writes Not Before, Not After and Renewal Time of the Certificate resource,
+// each with how far it is from the time the status was built
+func (status *CertificateStatus) writeValidity(sw *statusWriter) {
+	var startsIn, expiresIn, renewalIn *DurationStatus
+	if validity := status.Validity; validity != nil {
+		startsIn, expiresIn, renewalIn = validity.startsIn(), validity.ExpiresIn, validity.RenewalIn
+	}
+	fmt.Fprintf(sw, "Not Before: %s\n", formatTimeOrNotSet(status.NotBefore, startsIn))
+	fmt.Fprintf(sw, "Not After: %s\n", formatTimeOrNotSet(status.NotAfter, expiresIn))
+
+	switch {
+	case status.RenewalTime == nil:
+		fmt.Fprint(sw, "Renewal Time: not set\n")
+	case renewalIn != nil && renewalIn.Seconds < 0:
+		fmt.Fprintf(sw, "Renewal Time: cert-manager was due to renew at %s\n", formatTimeWithDuration(status.RenewalTime, renewalIn))
+	default:
+		fmt.Fprintf(sw, "Renewal Time: cert-manager will renew at %s\n", formatTimeWithDuration(status.RenewalTime, renewalIn))
+	}
+
+	if status.RequestedDuration != nil {
+		fmt.Fprintf(sw, "Requested Duration: %s\n", formatDurationStatus(status.RequestedDuration))
+	}
+	if status.ActualDuration != nil {
+		fmt.Fprintf(sw, "Actual Duration: %s\n", formatDurationStatus(status.ActualDuration))
+	}
+}
+
+// formatDurationStatus formats d like kubectl formats ages, e.g. 90d
+func formatDurationStatus(d *DurationStatus) string {
+	return duration.HumanDuration(time.Duration(d.Seconds) * time.Second)
+}
+
+// startsIn returns the time until the Certificate becomes valid, negative if it already is,
+// or nil if Not Before or Not After is not set
+func (validity *ValidityStatus) startsIn() *DurationStatus {
+	if validity.ExpiresIn == nil || validity.Lifetime == nil {
+		return nil
+	}
+	return newDurationStatus(time.Duration(validity.ExpiresIn.Seconds-validity.Lifetime.Seconds) * time.Second)
+}
+
+// formatTimeOrNotSet is formatTimeWithDuration, except that it returns "not set" if t is nil
+func formatTimeOrNotSet(t *metav1.Time, d *DurationStatus) string {
+	if t == nil {
+		return "not set"
+	}
+	return formatTimeWithDuration(t, d)
+}
+
+// formatTimeWithDuration returns the time as a string, followed by how far it is from now if d is not nil.
+// If t is nil, return "<none>"
+func formatTimeWithDuration(t *metav1.Time, d *DurationStatus) string {
+	if t == nil || d == nil {
+		return formatTimeString(t)
+	}
+	relative := time.Duration(d.Seconds) * time.Second
+	if relative < 0 {
+		return fmt.Sprintf("%s (%s ago)", formatTimeString(t), duration.HumanDuration(-relative))
+	}
+	return fmt.Sprintf("%s (in %s)", formatTimeString(t), duration.HumanDuration(relative))
+}
+
+// formatConditionTransition returns when a Condition last changed its status and for how long it has had it
+// at now, e.g. ", Last Transition: 2020-07-01T00:00:00Z (for 2h13m)", to be appended to the line of the Condition.
+// The age is left out if now is zero, and nothing is returned if lastTransitionTime is not set.
+func formatConditionTransition(lastTransitionTime *metav1.Time, now time.Time) string {
+	if lastTransitionTime == nil {
+		return ""
+	}
+	if now.IsZero() {
+		return ", Last Transition: " + formatTimeString(lastTransitionTime)
+	}
+	return fmt.Sprintf(", Last Transition: %s (for %s)", formatTimeString(lastTransitionTime),
+		duration.HumanDuration(now.Sub(lastTransitionTime.Time)))
+}
+
+// WriteTo writes the information about the status of a Issuer/ClusterIssuer to w to be printed as output
+func (issuerStatus *IssuerStatus) WriteTo(w io.Writer) (int64, error) {
+	return withTabWriter(w, func(sw *statusWriter) {
+		if issuerStatus.Error != nil {
+			fmt.Fprint(sw, issuerStatus.Error.Error())
+			return
+		}
+
+		fmt.Fprintf(sw, "Issuer:\n  Name: %s\n  Kind: %s\n", issuerStatus.Name, issuerStatus.Kind)
+		if issuerStatus.Group != "" {
+			fmt.Fprintf(sw, "  Group: %s\n", issuerStatus.Group)
+		}
+		if issuerStatus.External {
+			fmt.Fprintf(sw, "  Note: this is an external issuer, which is not looked up. To get more information about it, try 'kubectl describe %s.%s %s'\n",
+				strings.ToLower(issuerStatus.Kind), issuerStatus.Group, issuerStatus.Name)
+			return
+		}
+		if issuerStatus.Type == "" {
+			fmt.Fprint(sw, "  Type: unknown issuer type\n")
+		} else {
+			fmt.Fprintf(sw, "  Type: %s\n", issuerStatus.Type)
+		}
+		if issuerStatus.Endpoint != "" {
+			fmt.Fprintf(sw, "  Endpoint: %s\n", issuerStatus.Endpoint)
+		}
+		fmt.Fprint(sw, "  Conditions:\n")
+		if len(issuerStatus.Conditions) == 0 {
+			fmt.Fprint(sw, "    No Conditions set\n")
+		}
+		for _, con := range sortedIssuerConditions(issuerStatus.Conditions) {
+			fmt.Fprintf(sw, "    %s, Reason: %s, Message: %s%s\n", sw.conditionStatus(string(con.Type), con.Status), con.Reason, con.Message,
+				formatConditionTransition(con.LastTransitionTime, issuerStatus.ObservedAt))
+		}
+		if len(issuerStatus.SameNameIssuerNamespaces) > 0 {
+			fmt.Fprintf(sw, "  Note: namespaced Issuers with the same name exist in namespaces: %s\n",
+				strings.Join(issuerStatus.SameNameIssuerNamespaces, ", "))
+		}
+		if issuerStatus.CAStatus != nil {
+			fmt.Fprint(sw, issuerStatus.CAStatus.String())
+		}
+		if !issuerStatus.HideEvents {
+			writeEvents(sw, issuerStatus.Events, issuerStatus.SuppressedEvents, 1, issuerStatus.ObservedAt)
+		}
+	})
+}
+
+// String returns the information about the status of a Issuer/ClusterIssuer as a string to be printed as output
+func (issuerStatus *IssuerStatus) String() string {
+	return writerToString(issuerStatus)
+}
+
+// ConditionsMap returns the status of each Condition of the Issuer/ClusterIssuer by its type, e.g. "Ready": "True".
+// Returns nil if no Conditions are set.
+func (issuerStatus *IssuerStatus) ConditionsMap() map[string]string {
+	if len(issuerStatus.Conditions) == 0 {
+		return nil
+	}
+	conditions := make(map[string]string, len(issuerStatus.Conditions))
+	for _, con := range issuerStatus.Conditions {
+		conditions[string(con.Type)] = string(con.Status)
+	}
+	return conditions
+}
+
+// IsReady returns whether the Ready condition of the Issuer/ClusterIssuer is True.
+// ok is false if the Ready condition is not set or its status is Unknown.
+func (issuerStatus *IssuerStatus) IsReady() (ready bool, ok bool) {
+	for _, con := range issuerStatus.Conditions {
+		if con.Type == cmapi.IssuerConditionReady && con.Status != cmmeta.ConditionUnknown {
+			return con.Status == cmmeta.ConditionTrue, true
+		}
+	}
+	return false, false
+}
+
+// issuerCANearExpiryThreshold is the time before expiry of the CA certificate of an Issuer
+// from which on a warning is printed
+const issuerCANearExpiryThreshold = 30 * 24 * time.Hour
+
+// String returns the information about the CA certificate of an Issuer/ClusterIssuer as a string to be printed as output
+func (caStatus *IssuerCAStatus) String() string {
+	if caStatus.Error != nil {
+		return "  " + caStatus.Error.Error()
+	}
+
+	output := "  CA Certificate:\n"
+	output += fmt.Sprintf("    Secret: %s\n", caStatus.SecretName)
+	output += fmt.Sprintf("    Not After: %s\n", caStatus.NotAfter.Format(time.RFC3339))
+	if caStatus.ExpiresIn <= 0 {
+		output += fmt.Sprintf("    Expired: %s ago\n", duration.HumanDuration(-caStatus.ExpiresIn))
+	} else {
+		output += fmt.Sprintf("    Expires In: %s\n", duration.HumanDuration(caStatus.ExpiresIn))
+	}
+	return output
+}
+
+// String returns the information about the next private key as a string to be printed as output
+func (keyStatus *NextPrivateKeyStatus) String() string {
+	if keyStatus.Error != nil {
+		return "Next Private Key:\n  " + keyStatus.Error.Error()
+	}
+
+	output := "Next Private Key:\n"
+	output += fmt.Sprintf("  Secret: %s\n", keyStatus.SecretName)
+	switch {
+	case keyStatus.KeyCurve != "":
+		output += fmt.Sprintf("  Key: %s (%s)\n", keyStatus.KeyAlgorithm, keyStatus.KeyCurve)
+	case keyStatus.KeyBits > 0:
+		output += fmt.Sprintf("  Key: %s (%d bit)\n", keyStatus.KeyAlgorithm, keyStatus.KeyBits)
+	default:
+		output += fmt.Sprintf("  Key: %s\n", keyStatus.KeyAlgorithm)
+	}
+	return output
+}
+
+// WriteTo writes the information about the status of a Secret to w to be printed as output
+func (secretStatus *SecretStatus) WriteTo(w io.Writer) (int64, error) {
+	return withTabWriter(w, func(sw *statusWriter) {
+		if secretStatus.Error != nil {
+			fmt.Fprint(sw, secretStatus.Error.Error())
+			return
+		}
+
+		if secretStatus.PEMFile {
+			fmt.Fprintf(sw, "Certificate:\n  File: %s\n", secretStatus.File)
+		} else {
+			fmt.Fprintf(sw, "Secret:\n  Name: %s\n  Type: %s\n", secretStatus.Name, secretStatus.Type)
+			// Without a Certificate, as when read from a file, there is nothing the Secret could be owned by
+			if secretStatus.File == "" {
+				fmt.Fprintf(sw, "  Owned by Certificate: %t\n", secretStatus.OwnedByCertificate)
+			} else {
+				fmt.Fprintf(sw, "  File: %s\n", secretStatus.File)
+			}
+		}
+
+		secretFormat := `  Issuer Country: %s
+  Issuer Organisation: %s
+  Issuer Common Name: %s
+  Subject Country: %s
+  Subject Organisation: %s
+  Subject Organisational Unit: %s
+  Subject Common Name: %s
+  Subject Alternative Names:
+    DNS Names: %s
+    IP Addresses: %s
+    URIs: %s
+    Email Addresses: %s
+  Key Usage: %s
+  Extended Key Usages: %s
+`
+		secretFormatAfterExtKeyUsages := `  Basic Constraints: %s
+  Public Key Algorithm: %s%s
+  Signature Algorithm: %s
+  Subject Key ID: %s
+  Authority Key ID: %s
+  Version: %d
+  Serial Number: %s
+  Serial Number (Decimal): %s
+  Fingerprint (%s): %s
+`
+
+		// Unknown Extended Usages are already named in the string
+		extKeyUsageString, _ := extKeyUsageToString(secretStatus.ExtKeyUsage)
+		serialNumberString := SerialNumberToString(secretStatus.SerialNumber)
+		if secretStatus.SerialNumberDER {
+			derSerialNumber, err := derSerialNumberToString(secretStatus.SerialNumber)
+			if err != nil {
+				derSerialNumber = err.Error()
+			}
+			serialNumberString = derSerialNumber
+		}
+		publicKeySizeString := ""
+		switch {
+		case secretStatus.KeyCurve != "":
+			publicKeySizeString = fmt.Sprintf(" (%s)", secretStatus.KeyCurve)
+		case secretStatus.KeyBits > 0:
+			publicKeySizeString = fmt.Sprintf(" (%d bit)", secretStatus.KeyBits)
+		}
+		fingerprintAlgorithm, fingerprint := "SHA-256", secretStatus.FingerprintSHA256
+		if secretStatus.FingerprintAlgorithm == fingerprintAlgorithmSHA1 {
+			fingerprintAlgorithm, fingerprint = "SHA-1", secretStatus.FingerprintSHA1
+		}
+		fmt.Fprintf(sw, secretFormat, joinSorted(secretStatus.IssuerCountry),
+			joinSorted(secretStatus.IssuerOrganisation),
+			secretStatus.IssuerCommonName, joinSorted(secretStatus.SubjectCountry),
+			joinSorted(secretStatus.SubjectOrganisation), joinSorted(secretStatus.SubjectOrganizationalUnit),
+			secretStatus.SubjectCommonName, joinSorted(secretStatus.DNSNames),
+			joinSorted(secretStatus.IPAddresses), joinSorted(secretStatus.URIs),
+			joinSorted(secretStatus.EmailAddresses), keyUsageToString(secretStatus.KeyUsage),
+			extKeyUsageString)
+		// Extended Key Usages that crypto/x509 does not know are listed by OID under the line of the known ones
+		for _, oid := range oidsToStrings(secretStatus.UnknownExtKeyUsage) {
+			fmt.Fprintf(sw, "    OID: %s\n", oid)
+		}
+		fmt.Fprintf(sw, secretFormatAfterExtKeyUsages, secretStatus.basicConstraints(), secretStatus.PublicKeyAlgorithm, publicKeySizeString, secretStatus.SignatureAlgorithm,
+			formatHexColon(secretStatus.SubjectKeyId), formatHexColon(secretStatus.AuthorityKeyId),
+			secretStatus.Version, serialNumberString, serialNumberToDecimalString(secretStatus.SerialNumber), fingerprintAlgorithm, fingerprint)
+		secretStatus.writeAuthorityInformation(sw)
+		secretStatus.writeNameConstraints(sw)
+		if len(secretStatus.PolicyIdentifiers) > 0 {
+			fmt.Fprintf(sw, "  Certificate Policies: %s\n", joinSorted(oidsToStrings(secretStatus.PolicyIdentifiers)))
+		}
+		switch {
+		case secretStatus.NotYetValid:
+			fmt.Fprintf(sw, "  Validity: %s\n", sw.colorize(colorRed, "NOT YET VALID, valid from "+secretStatus.NotBefore.Format(time.RFC3339)))
+		case secretStatus.Expired:
+			fmt.Fprintf(sw, "  Validity: %s\n", sw.colorize(colorRed, fmt.Sprintf("EXPIRED %s ago (%s)",
+				duration.HumanDuration(-secretStatus.ExpiresIn), secretStatus.NotAfter.Format(time.RFC3339))))
+		default:
+			fmt.Fprintf(sw, "  Validity: Expires in %s (%s)\n", duration.HumanDuration(secretStatus.ExpiresIn), secretStatus.NotAfter.Format(time.RFC3339))
+		}
+		if secretStatus.KeyMatchesCert != nil {
+			fmt.Fprintf(sw, "  Private Key Matches Certificate: %t\n", *secretStatus.KeyMatchesCert)
+		}
+		if secretStatus.IsPrecertificate {
+			fmt.Fprint(sw, "  Precertificate: true\n")
+		}
+		if secretStatus.SignaturePolicy != nil {
+			fmt.Fprint(sw, secretStatus.SignaturePolicy.String())
+		}
+		if len(secretStatus.MissingPolicies) > 0 {
+			fmt.Fprintf(sw, "  Missing Certificate Policies: %s\n", strings.Join(secretStatus.MissingPolicies, ", "))
+		}
+		if secretStatus.Keystores != nil {
+			fmt.Fprint(sw, secretStatus.Keystores.String())
+		}
+		if secretStatus.CAValidity != nil {
+			fmt.Fprint(sw, secretStatus.CAValidity.String())
+		}
+		if secretStatus.CACertificate != nil {
+			fmt.Fprint(sw, secretStatus.CACertificate.String())
+		}
+		if len(secretStatus.Chain) > 0 {
+			fmt.Fprint(sw, "  Chain:\n")
+			for i, cert := range secretStatus.Chain {
+				fmt.Fprintf(sw, "    %d: Subject: %s, Issuer: %s\n", i, cert.Subject, cert.Issuer)
+			}
+		}
+		switch {
+		case secretStatus.ChainVerified:
+			fmt.Fprintf(sw, "  Chain Verification: %s, %d certificates up to a trusted root\n", sw.colorize(colorGreen, "OK"), secretStatus.ChainLength)
+		case secretStatus.ChainError != "":
+			fmt.Fprintf(sw, "  Chain Verification: %s, %s\n", sw.colorize(colorRed, "FAILED"), secretStatus.ChainError)
+		}
+		if len(secretStatus.Annotations) > 0 {
+			fmt.Fprint(sw, "  Annotations:\n")
+			keys := make([]string, 0, len(secretStatus.Annotations))
+			for key := range secretStatus.Annotations {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				fmt.Fprintf(sw, "    %s: %s\n", key, secretStatus.Annotations[key])
+			}
+		}
+		if secretStatus.RawX509 && secretStatus.x509Cert != nil {
+			writeRawX509(sw, secretStatus.x509Cert)
+		}
+		if !secretStatus.HideEvents {
+			writeEvents(sw, secretStatus.Events, secretStatus.SuppressedEvents, 1, secretStatus.ObservedAt)
+		}
+	})
+}
+
+// String returns the information about the status of a Secret as a string to be printed as output
+func (secretStatus *SecretStatus) String() string {
+	return writerToString(secretStatus)
+}
+
+const (
+	fingerprintAlgorithmSHA256 = "sha256"
+	fingerprintAlgorithmSHA1   = "sha1"
+)
+
+// fingerprintAlgorithms are the hash algorithms the fingerprint of the certificate can be printed with,
+// SHA-1 only being offered for interoperability with legacy tools
+var fingerprintAlgorithms = []string{fingerprintAlgorithmSHA256, fingerprintAlgorithmSHA1}
+
+// isFingerprintAlgorithm returns true if algorithm is one of fingerprintAlgorithms
+func isFingerprintAlgorithm(algorithm string) bool {
+	for _, a := range fingerprintAlgorithms {
+		if a == algorithm {
+			return true
+		}
+	}
+	return false
+}
+
+// joinSorted joins a sorted copy of values with ", ", so that the text output does not depend on the order
+// the values were parsed in. values itself is not reordered, so the JSON output keeps that order
+func joinSorted(values []string) string {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
+
+// conditionTypeLess orders the types of conditions as printed: Ready first, as the one most looked for,
+// then the others alphabetically
+func conditionTypeLess(a, b string) bool {
+	if a == "Ready" || b == "Ready" {
+		return a == "Ready" && b != "Ready"
+	}
+	return a < b
+}
+
+// sortedCertificateConditions returns a copy of conditions ordered by type with conditionTypeLess
+func sortedCertificateConditions(conditions []cmapi.CertificateCondition) []cmapi.CertificateCondition {
+	sorted := append([]cmapi.CertificateCondition(nil), conditions...)
+	sort.SliceStable(sorted, func(i, j int) bool { return conditionTypeLess(string(sorted[i].Type), string(sorted[j].Type)) })
+	return sorted
+}
+
+// sortedIssuerConditions returns a copy of conditions ordered by type with conditionTypeLess
+func sortedIssuerConditions(conditions []cmapi.IssuerCondition) []cmapi.IssuerCondition {
+	sorted := append([]cmapi.IssuerCondition(nil), conditions...)
+	sort.SliceStable(sorted, func(i, j int) bool { return conditionTypeLess(string(sorted[i].Type), string(sorted[j].Type)) })
+	return sorted
+}
+
+// sortedCRConditions returns a copy of conditions ordered by type with conditionTypeLess
+func sortedCRConditions(conditions []cmapi.CertificateRequestCondition) []cmapi.CertificateRequestCondition {
+	sorted := append([]cmapi.CertificateRequestCondition(nil), conditions...)
+	sort.SliceStable(sorted, func(i, j int) bool { return conditionTypeLess(string(sorted[i].Type), string(sorted[j].Type)) })
+	return sorted
+}
+
+// formatHexColon returns b as colon separated pairs of upper case hex digits, e.g. 1A:2B:3C,
+// the way openssl prints serial numbers, key identifiers and fingerprints
+func formatHexColon(b []byte) string {
+	parts := make([]string, len(b))
+	for i, octet := range b {
+		parts[i] = fmt.Sprintf("%02X", octet)
+	}
+	return strings.Join(parts, ":")
+}
+
+// SerialNumberToString returns serial as colon separated hex, as printed by the status commands.
+// Its minimal big-endian encoding is used, so a serial of zero, which big.Int encodes as no bytes, is printed as 00.
+func SerialNumberToString(serial *big.Int) string {
+	b := serial.Bytes()
+	if len(b) == 0 {
+		b = []byte{0}
+	}
+	// Bytes drops the sign, which the serial numbers of certificates issued by broken CAs can have
+	if serial.Sign() < 0 {
+		return "-" + formatHexColon(b)
+	}
+	return formatHexColon(b)
+}
+
+// serialNumberToDecimalString returns serial in decimal, the way openssl prints serial numbers that fit in a long
+func serialNumberToDecimalString(serial *big.Int) string {
+	if serial == nil {
+		return ""
+	}
+	return serial.String()
+}
+
+// derSerialNumberToString returns the content of the DER INTEGER encoding of serial as colon separated hex,
+// which has a leading zero byte if the high bit of a positive serial is set
+func derSerialNumberToString(serial *big.Int) (string, error) {
+	der, err := asn1.Marshal(serial)
+	if err != nil {
+		return "", fmt.Errorf("error when encoding serial number: %w", err)
+	}
+	var raw asn1.RawValue
+	if _, err := asn1.Unmarshal(der, &raw); err != nil {
+		return "", fmt.Errorf("error when decoding serial number: %w", err)
+	}
+	return formatHexColon(raw.Bytes), nil
+}
+
+// String returns the strength tier of the signature algorithm and the policy decision as a string to be printed as output
+func (policy *SignaturePolicyStatus) String() string {
+	if policy.Compliant {
+		return fmt.Sprintf("  Signature Strength: %s, meets the minimum strength %s required by policy\n", policy.Strength, policy.MinStrength)
+	}
+	return fmt.Sprintf("  Signature Strength: %s, below the minimum strength %s required by policy\n", policy.Strength, policy.MinStrength)
+}
+
+// String returns the information about the keystores in the Secret as a string to be printed as output
+func (keystores *KeystoresStatus) String() string {
+	output := "  Keystores:\n"
+	if keystores.PKCS12Size > 0 {
+		output += fmt.Sprintf("    %s: %d bytes", pkcs12SecretKey, keystores.PKCS12Size)
+		switch {
+		case keystores.PKCS12Error != "":
+			output += ", could not be decoded with the password given"
+		case keystores.PKCS12MatchesCert != nil && *keystores.PKCS12MatchesCert:
+			output += ", holds the certificate in 'tls.crt'"
+		case keystores.PKCS12MatchesCert != nil:
+			output += ", does not hold the certificate in 'tls.crt'"
+		}
+		output += "\n"
+	}
+	if keystores.JKSSize > 0 {
+		output += fmt.Sprintf("    %s: %d bytes\n", jksSecretKey, keystores.JKSSize)
+	}
+	return output
+}
+
+// String returns the comparison between the validity of the x509 certificate in the Secret
+// and the validity of its CA certificate as a string to be printed as output
+func (caValidity *CAValidityStatus) String() string {
+	output := fmt.Sprintf("  CA Validity (from %s):\n", caValidity.Source)
+	output += fmt.Sprintf("    Not Before: %s\n", caValidity.NotBefore.Format(time.RFC3339))
+	output += fmt.Sprintf("    Not After: %s\n", caValidity.NotAfter.Format(time.RFC3339))
+	output += fmt.Sprintf("    Certificate Trusted Until: %s\n", caValidity.TrustedUntil.Format(time.RFC3339))
+	return output
+}
+
+// String returns the information about the CA certificate in 'ca.crt' of the Secret as a string to be printed as output,
+// indented to sit in the Secret block
+func (caCertificate *CACertificateStatus) String() string {
+	if caCertificate.Error != nil {
+		return "  CA Certificate: " + caCertificate.Error.Error()
+	}
+	output := "  CA Certificate:\n"
+	output += fmt.Sprintf("    Subject Common Name: %s\n", caCertificate.SubjectCommonName)
+	output += fmt.Sprintf("    Issuer Common Name: %s\n", caCertificate.IssuerCommonName)
+	output += fmt.Sprintf("    Not After: %s\n", caCertificate.NotAfter.Format(time.RFC3339))
+	output += fmt.Sprintf("    Serial Number: %s\n", SerialNumberToString(caCertificate.SerialNumber))
+	return output
+}
+
+var (
+	// oidExtensionCTPoison is the OID of the Certificate Transparency precertificate
+	// poison extension, see RFC 6962 section 3.1
+	oidExtensionCTPoison = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 3}
+
+	keyUsageToStringMap = map[int]string{
+		1:   "Digital Signature",
+		2:   "Content Commitment",
+		4:   "Key Encipherment",
+		8:   "Data Encipherment",
+		16:  "Key Agreement",
+		32:  "Cert Sign",
+		64:  "CRL Sign",
+		128: "Encipher Only",
+		256: "Decipher Only",
+	}
+	// keyUsagePossibleValues lists the key usage bits in the order they are printed
+	keyUsagePossibleValues  = []int{1, 2, 4, 8, 16, 32, 64, 128, 256}
+	extKeyUsageStringValues = []string{"Any", "Server Authentication", "Client Authentication", "Code Signing", "Email Protection",
+		"IPSEC End System", "IPSEC Tunnel", "IPSEC User", "Time Stamping", "OCSP Signing", "Microsoft Server Gated Crypto",
+		"Netscape Server Gated Crypto", "Microsoft Commercial Code Signing", "Microsoft Kernel Code Signing",
+	}
+)
+
+// hasCTPoisonExtension returns true if cert carries the Certificate Transparency
+// precertificate poison extension
+func hasCTPoisonExtension(cert *x509.Certificate) bool {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidExtensionCTPoison) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyUsageToString returns the names of the key usages set in usage, in the order of keyUsagePossibleValues.
+// Bits that are not a known key usage are ignored.
+func keyUsageToString(usage x509.KeyUsage) string {
+	var usageStrings []string
+	for _, val := range keyUsagePossibleValues {
+		if int(usage)&val != 0 {
+			usageStrings = append(usageStrings, keyUsageToStringMap[val])
+		}
+	}
+	return strings.Join(usageStrings, ", ")
+}
+
+// extKeyUsageToString returns the names of extUsages. Unknown Extended Usages are named "Unknown (N)" with N their code,
+// in which case the string is still usable and an error listing their codes is returned as well.
+func extKeyUsageToString(extUsages []x509.ExtKeyUsage) (string, error) {
+	var extUsageStrings, unknownCodes []string
+	for _, extUsage := range extUsages {
+		if extUsage < 0 || int(extUsage) >= len(extKeyUsageStringValues) {
+			extUsageStrings = append(extUsageStrings, fmt.Sprintf("Unknown (%d)", extUsage))
+			unknownCodes = append(unknownCodes, strconv.Itoa(int(extUsage)))
+			continue
+		}
+		extUsageStrings = append(extUsageStrings, extKeyUsageStringValues[extUsage])
+	}
+	var err error
+	if len(unknownCodes) > 0 {
+		err = fmt.Errorf("encountered unknown Extended Usages with codes %s", strings.Join(unknownCodes, ", "))
+	}
+	return strings.Join(extUsageStrings, ", "), err
+}
+
+// oidsToStrings returns the OIDs in dotted notation
+func oidsToStrings(oids []asn1.ObjectIdentifier) []string {
+	var oidStrings []string
+	for _, oid := range oids {
+		oidStrings = append(oidStrings, oid.String())
+	}
+	return oidStrings
+}
+
+// WriteTo writes the information about the status of a CR to w to be printed as output
+func (crStatus *CRStatus) WriteTo(w io.Writer) (int64, error) {
+	return withTabWriter(w, func(sw *statusWriter) {
+		if crStatus.Error != nil {
+			fmt.Fprint(sw, crStatus.Error.Error())
+			return
+		}
+
+		fmt.Fprintf(sw, "CertificateRequest:\n  Name: %s\n  Namespace: %s\n  Conditions:\n", crStatus.Name, crStatus.Namespace)
+		if len(crStatus.Conditions) == 0 {
+			fmt.Fprint(sw, "    No Conditions set\n")
+		}
+		for _, con := range sortedCRConditions(crStatus.Conditions) {
+			fmt.Fprintf(sw, "    %s, Reason: %s, Message: %s%s\n", sw.conditionStatus(string(con.Type), con.Status), con.Reason, con.Message,
+				formatConditionTransition(con.LastTransitionTime, crStatus.ObservedAt))
+		}
+		if crStatus.Requested != nil {
+			fmt.Fprint(sw, crStatus.Requested.String())
+		}
+		if !crStatus.HideEvents {
+			writeEvents(sw, crStatus.Events, crStatus.SuppressedEvents, 1, crStatus.ObservedAt)
+		}
+	})
+}
+
+// writeCRHistory writes a line with the revision, name, state and age of each CertificateRequest in the history,
+// followed by the details of each if CRHistoryDetails is set
+func (status *CertificateStatus) writeCRHistory(sw *statusWriter) {
+	if len(status.CRHistory) == 0 {
+		return
+	}
+	fmt.Fprint(sw, "CertificateRequest History:\n  Revision\tName\tState\tAge\n")
+	for _, crStatus := range status.CRHistory {
+		fmt.Fprintf(sw, "  %d\t%s\t%s\t%s\n", crStatus.Revision, crStatus.Name, crStatus.state(), duration.HumanDuration(crStatus.Age))
+	}
+	if status.CRHistoryDetails {
+		for _, crStatus := range status.CRHistory {
+			crStatus.WriteTo(sw)
+		}
+	}
+}
+
+// state returns the reason of the Ready condition of the CertificateRequest, e.g. Pending, Issued or Failed,
+// its status if there is no reason, or Unknown if there is no Ready condition
+func (crStatus *CRStatus) state() string {
+	for _, con := range crStatus.Conditions {
+		if con.Type != cmapi.CertificateRequestConditionReady {
+			continue
+		}
+		if con.Reason != "" {
+			return con.Reason
+		}
+		return string(con.Status)
+	}
+	return "Unknown"
+}
+
+// String returns the information about the status of a CR as a string to be printed as output
+func (crStatus *CRStatus) String() string {
+	return writerToString(crStatus)
+}
+
+// String returns the information about what was requested by a CR as a string to be printed as output
+func (requestedStatus *RequestedStatus) String() string {
+	if requestedStatus.Error != nil {
+		return "  " + requestedStatus.Error.Error()
+	}
+
+	output := "  Requested:\n"
+	output += fmt.Sprintf("    Common Name: %s\n", requestedStatus.CommonName)
+	output += fmt.Sprintf("    DNS Names: %s\n", joinSorted(requestedStatus.DNSNames))
+	output += fmt.Sprintf("    IP Addresses: %s\n", joinSorted(requestedStatus.IPAddresses))
+	output += fmt.Sprintf("    URIs: %s\n", joinSorted(requestedStatus.URIs))
+	output += fmt.Sprintf("    Email Addresses: %s\n", joinSorted(requestedStatus.EmailAddresses))
+	output += fmt.Sprintf("    Usages: %s\n", formatKeyUsages(requestedStatus.Usages))
+	output += fmt.Sprintf("    Is CA: %t\n", requestedStatus.IsCA)
+	return output
+}
+
+// formatKeyUsages formats the usages of a CertificateRequest, which are the default usages if none are set
+func formatKeyUsages(usages []cmapi.KeyUsage) string {
+	suffix := ""
+	if len(usages) == 0 {
+		usages, suffix = cmapi.DefaultKeyUsages(), " (default)"
+	}
+	usageStrings := make([]string, len(usages))
+	for i, usage := range usages {
+		usageStrings[i] = string(usage)
+	}
+	return strings.Join(usageStrings, ", ") + suffix
+}
+
+// String returns the information about the status of a CR as a string to be printed as output
+func (orderStatus *OrderStatus) String() string {
+	if orderStatus.Error != nil {
+		return orderStatus.Error.Error()
+	}
+
+	output := "Order:\n"
+	output += fmt.Sprintf("  Name: %s\n", orderStatus.Name)
+	output += fmt.Sprintf("  State: %s, Reason: %s\n", orderStatus.State, orderStatus.Reason)
+	authString := ""
+	for _, auth := range orderStatus.Authorizations {
+		wildcardString := "nil (bool pointer not set)"
+		if auth.Wildcard != nil {
+			wildcardString = fmt.Sprintf("%t", *auth.Wildcard)
+		}
+		authString += fmt.Sprintf("    URL: %s, Identifier: %s, Initial State: %s, Wildcard: %s\n", auth.URL, auth.Identifier, auth.InitialState, wildcardString)
+	}
+	if authString == "" {
+		output += "  No Authorizations for this Order\n"
+	} else {
+		output += "  Authorizations:\n"
+		output += authString
+	}
+	if orderStatus.FailureTime != nil {
+		output += fmt.Sprintf("  FailureTime: %s\n", formatTimeString(orderStatus.FailureTime))
+	}
+
+	return output
+}
+
+func (c *ChallengeStatusList) String() string {
+	if c.Error != nil {
+		return c.Error.Error()
+	}
+
+	challengeStrings := []string{}
+	for _, challengeStatus := range c.ChallengeStatuses {
+		challengeStrings = append(challengeStrings, challengeStatus.String())
+	}
+	output := "Challenges:\n"
+	output += formatStringSlice(challengeStrings)
+	return output
+}
+
+func (challengeStatus *ChallengeStatus) String() string {
+	output := fmt.Sprintf("Name: %s, Type: %s, DNS Name: %s, Token: %s, Key: %s, State: %s, Reason: %s, Processing: %t, Presented: %t",
+		challengeStatus.Name, challengeStatus.Type, challengeStatus.DNSName, challengeStatus.Token, challengeStatus.Key, challengeStatus.State,
+		challengeStatus.Reason, challengeStatus.Processing, challengeStatus.Presented)
+	if challengeStatus.DNSCheck != nil {
+		output += "\n" + strings.TrimSuffix(challengeStatus.DNSCheck.String(), "\n")
+	}
+	return output
+}
+
+// String returns the expected and observed values of the TXT record of a DNS01 challenge
+// as a string to be printed as output, indented to sit below its challenge
+func (dnsCheck *DNSCheckStatus) String() string {
+	output := fmt.Sprintf("  DNS Check of %s:\n", dnsCheck.FQDN)
+	output += fmt.Sprintf("    Expected: %q\n", dnsCheck.Expected)
+	for _, lookup := range dnsCheck.Lookups {
+		if lookup.Error != nil {
+			output += fmt.Sprintf("    %s: error: %s\n", lookup.Nameserver, lookup.Error)
+			continue
+		}
+		propagated := "not propagated"
+		for _, record := range lookup.Records {
+			if record == dnsCheck.Expected {
+				propagated = "propagated"
+			}
+		}
+		observed := "<none>"
+		if len(lookup.Records) > 0 {
+			quoted := make([]string, len(lookup.Records))
+			for i, record := range lookup.Records {
+				quoted[i] = fmt.Sprintf("%q", record)
+			}
+			observed = strings.Join(quoted, ", ")
+		}
+		output += fmt.Sprintf("    %s: %s, observed: %s\n", lookup.Nameserver, propagated, observed)
+	}
+	return output
+}
+
+// writeEvents writes events as a table indented by baseLevel to w, which should be a tabwriter to align the table.
+// If events were suppressed by --event-type, their number is written in the header of the table.
+// The age of the events is computed relative to now.
+func writeEvents(w io.Writer, events *v1.EventList, suppressed int, baseLevel int, now time.Time) {
+	header := "Events"
+	if suppressed > 0 {
+		header = fmt.Sprintf("Events (%d suppressed by --event-type)", suppressed)
+	}
+	util.DescribeEventsWithHeader(events, describe.NewPrefixWriter(w), baseLevel, header, now)
+}
+
+// statusWriter writes to w, counting the bytes written and keeping the first error,
+// so that the WriteTo methods need not check the result of every write
+type statusWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+	// Whether to write in color, see colorize
+	color bool
+}
+
+func (sw *statusWriter) Write(p []byte) (int, error) {
+	if sw.err != nil {
+		return 0, sw.err
+	}
+	n, err := sw.w.Write(p)
+	sw.n += int64(n)
+	sw.err = err
+	return n, err
+}
+
+// Flush flushes w if it is a tabwriter, so that flushes of the event tables reach it
+func (sw *statusWriter) Flush() {
+	if tw, ok := sw.w.(*tabwriter.Writer); ok && sw.err == nil {
+		sw.err = tw.Flush()
+	}
+}
+
+// withTabWriter calls write with a statusWriter on a tabwriter on w and flushes it. If w already writes
+// to a tabwriter, as when a CertificateStatus writes the statuses of its related resources, that one is shared.
+// Returns the number of bytes written to the tabwriter, before padding.
+func withTabWriter(w io.Writer, write func(sw *statusWriter)) (int64, error) {
+	if sw, ok := w.(*statusWriter); ok {
+		n := sw.n
+		write(sw)
+		return sw.n - n, sw.err
+	}
+	color := false
+	if cw, ok := w.(colorWriter); ok {
+		w, color = cw.Writer, true
+	}
+	tw := util.NewTabWriter(w)
+	sw := &statusWriter{w: tw, color: color}
+	write(sw)
+	sw.Flush()
+	return sw.n, sw.err
 }
 
-func eventsToString(events *v1.EventList, baseLevel int) string {
+// writerToString returns what s writes as a string
+func writerToString(s io.WriterTo) string {
 	var buf bytes.Buffer
-	defer buf.Reset()
-	tabWriter := util.NewTabWriter(&buf)
-	prefixWriter := describe.NewPrefixWriter(tabWriter)
-	util.DescribeEvents(events, prefixWriter, baseLevel)
-	tabWriter.Flush()
+	s.WriteTo(&buf)
 	return buf.String()
 }
@@ -0,0 +1,113 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/clock"
+)
+
+// watch prints the status built from data, then polls getData every o.Interval and prints the status
+// again whenever any of the resources it is built from changed. It returns the last status printed
+// once the Certificate is Ready or stop receives.
+func (o *Options) watch(out io.Writer, data *Data, getData func() (*Data, error), clock clock.Clock, stop <-chan os.Signal) (*CertificateStatus, error) {
+	var status *CertificateStatus
+	lastVersions := ""
+	for {
+		if versions := data.resourceVersions(); status == nil || versions != lastVersions {
+			lastVersions = versions
+			status = o.buildStatus(data, clock)
+			if err := o.writeRefresh(out, status, clock.Now()); err != nil {
+				return status, err
+			}
+		}
+		if ready, _ := status.IsReady(); ready {
+			return status, nil
+		}
+
+		select {
+		case <-stop:
+			return status, nil
+		case <-clock.After(o.Interval):
+		}
+
+		var err error
+		if data, err = getData(); err != nil {
+			return status, err
+		}
+	}
+}
+
+// writeRefresh writes status, built at now, to out as a refresh in watch mode.
+// Writers that buffer their output are flushed, so that consumers of the stream see each refresh as it happens.
+func (o *Options) writeRefresh(out io.Writer, status *CertificateStatus, now time.Time) error {
+	var err error
+	if o.Output == "jsonl" {
+		err = writeJSONLine(out, status, now)
+	} else {
+		if _, err = fmt.Fprintf(out, "=== %s ===\n", now.Format(time.RFC3339)); err != nil {
+			return err
+		}
+		err = o.writeStatus(out, status)
+	}
+	if err != nil {
+		return err
+	}
+	if flusher, ok := out.(interface{ Flush() error }); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+// resourceVersions returns the resource versions of all resources in data, including their events,
+// so that any change to them results in a different string
+func (data *Data) resourceVersions() string {
+	var versions []string
+	if data.Certificate != nil {
+		versions = append(versions, data.Certificate.ResourceVersion)
+	}
+	if data.Issuer != nil {
+		versions = append(versions, data.Issuer.GetResourceVersion())
+	}
+	if data.Secret != nil {
+		versions = append(versions, data.Secret.ResourceVersion)
+	}
+	if data.Req != nil {
+		versions = append(versions, data.Req.ResourceVersion)
+	}
+	if data.Order != nil {
+		versions = append(versions, data.Order.ResourceVersion)
+	}
+	for _, challenge := range data.Challenges {
+		versions = append(versions, challenge.ResourceVersion)
+	}
+	for _, events := range []*corev1.EventList{data.CrtEvents, data.IssuerEvents, data.SecretEvents, data.ReqEvents} {
+		if events == nil {
+			continue
+		}
+		for _, event := range events.Items {
+			versions = append(versions, event.ResourceVersion)
+		}
+	}
+	return strings.Join(versions, ",")
+}
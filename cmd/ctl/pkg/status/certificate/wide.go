@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/status/util"
+)
+
+// wideColumns are the columns of the table written by writeWide, one row per Certificate
+var wideColumns = []string{"NAME", "READY", "SECRET", "ISSUER", "EXPIRES", "RENEWAL", "REVISION"}
+
+// writeWide writes statuses to out as a table with a row per Certificate, like 'kubectl get -o wide'.
+// If withNamespace, the namespace of each Certificate is written in a first column.
+// Related resources that could not be looked up are noted in their column instead of failing the row.
+func writeWide(out io.Writer, statuses []*CertificateStatus, withNamespace bool) error {
+	tw := util.NewTabWriter(out)
+	columns := wideColumns
+	if withNamespace {
+		columns = append([]string{"NAMESPACE"}, columns...)
+	}
+	fmt.Fprintln(tw, strings.Join(columns, "\t"))
+	for _, status := range statuses {
+		row := status.wideRow()
+		if withNamespace {
+			row = append([]string{status.Namespace}, row...)
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// wideRow returns the cells of the row of the Certificate in the table written by writeWide
+func (status *CertificateStatus) wideRow() []string {
+	ready := "Unknown"
+	switch isReady, ok := status.IsReady(); {
+	case ok && isReady:
+		ready = "True"
+	case ok:
+		ready = "False"
+	}
+
+	secret := status.SecretName
+	expires := "<unknown>"
+	switch secretStatus := status.SecretStatus; {
+	case secretStatus == nil:
+	case secretStatus.Error != nil && apierrors.IsNotFound(secretStatus.Error):
+		secret += " (not found)"
+	case secretStatus.Error != nil:
+		secret += " (error)"
+	default:
+		expires = formatRelativeDuration(secretStatus.ExpiresIn)
+	}
+
+	issuer := "<none>"
+	switch issuerStatus := status.IssuerStatus; {
+	case issuerStatus == nil:
+	case issuerStatus.Error != nil:
+		issuer = "<error>"
+	default:
+		issuer = issuerStatus.Name
+	}
+
+	renewal := "<none>"
+	if status.Validity != nil && status.Validity.RenewalIn != nil {
+		renewal = formatRelativeDuration(time.Duration(status.Validity.RenewalIn.Seconds) * time.Second)
+	}
+
+	revision := "<none>"
+	if status.Revision != nil {
+		revision = strconv.Itoa(*status.Revision)
+	}
+	return []string{status.Name, ready, secret, issuer, expires, renewal, revision}
+}
+
+// formatRelativeDuration returns d as a human readable time relative to now, e.g. "in 29d" or "2h ago"
+func formatRelativeDuration(d time.Duration) string {
+	if d < 0 {
+		return duration.HumanDuration(-d) + " ago"
+	}
+	return "in " + duration.HumanDuration(d)
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/reference"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/utils/clock"
+
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/status/certificate"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1beta1"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+	"github.com/jetstack/cert-manager/pkg/ctl"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Get details about the current status of a cert-manager CertificateRequest resource, including the decoded certificate signing request, the issued certificate, the Certificate owning it, if any, and the ACME Order created for it, if any.`))
+
+	example = templates.Examples(i18n.T(`
+# Query status of CertificateRequest with name 'my-cr' in namespace 'my-namespace'
+kubectl cert-manager status certificaterequest my-cr --namespace my-namespace
+`))
+)
+
+// Options is a struct to support status certificaterequest command
+type Options struct {
+	CMClient   cmclient.Interface
+	RESTConfig *restclient.Config
+	// The Namespace that the CertificateRequest to be queried about resides in.
+	// This flag registration is handled by cmdutil.Factory
+	Namespace string
+
+	genericclioptions.IOStreams
+}
+
+// Data is a struct containing the information to build a CertificateRequestStatus
+type Data struct {
+	Req         *cmapi.CertificateRequest
+	ReqEvents   *corev1.EventList
+	Certificate *cmapi.Certificate
+	CrtError    error
+	Order       *cmacme.Order
+	OrderError  error
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdStatusCR returns a cobra command for status certificaterequest
+func NewCmdStatusCR(ioStreams genericclioptions.IOStreams, factory cmdutil.Factory) *cobra.Command {
+	o := NewOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:     "certificaterequest",
+		Aliases: []string{"cr"},
+		Short:   "Get details about the current status of a cert-manager CertificateRequest resource",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Complete(factory))
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the name of the CertificateRequest has to be provided as argument")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the name of the CertificateRequest")
+	}
+	return nil
+}
+
+// Complete takes the factory and infers any remaining options.
+func (o *Options) Complete(f cmdutil.Factory) error {
+	var err error
+
+	o.Namespace, _, err = f.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	o.RESTConfig, err = f.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	o.CMClient, err = cmclient.NewForConfig(o.RESTConfig)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run executes status certificaterequest command
+func (o *Options) Run(args []string) error {
+	data, err := o.GetResources(args[0])
+	if err != nil {
+		return err
+	}
+
+	status := StatusFromResources(data, clock.RealClock{})
+
+	fmt.Fprint(o.Out, status.String())
+
+	return nil
+}
+
+// GetResources collects the CertificateRequest, its events, the Certificate owning it, if any,
+// and the Order owned by it, if any, in a Data struct and returns it.
+// Returns error if error occurs when finding the CertificateRequest resource or its events.
+func (o *Options) GetResources(crName string) (*Data, error) {
+	ctx := context.TODO()
+
+	clientSet, err := kubernetes.NewForConfig(o.RESTConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := o.CMClient.CertmanagerV1().CertificateRequests(o.Namespace).Get(ctx, crName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error when getting CertificateRequest resource: %v", err)
+	}
+
+	reqRef, err := reference.GetReference(ctl.Scheme, req)
+	if err != nil {
+		return nil, err
+	}
+	// If no events found, reqEvents would be nil and handled down the line in DescribeEvents
+	reqEvents, err := clientSet.CoreV1().Events(req.Namespace).Search(ctl.Scheme, reqRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		crt    *cmapi.Certificate
+		crtErr error
+	)
+	if owner := metav1.GetControllerOf(req); owner != nil && owner.Kind == cmapi.CertificateKind {
+		crt, crtErr = o.CMClient.CertmanagerV1().Certificates(req.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+		if crtErr != nil {
+			crtErr = fmt.Errorf("error when getting Certificate %q owning the CertificateRequest: %v\n", owner.Name, crtErr)
+		}
+	}
+
+	// Only ACME Issuers create an Order for a CertificateRequest, so there is nothing to output
+	// about the Order if none is found or the ACME CRDs are not installed
+	order, orderErr := certificate.FindMatchingOrder(o.CMClient, ctx, req)
+	if apierrors.IsNotFound(orderErr) {
+		order, orderErr = nil, nil
+	} else if orderErr != nil {
+		orderErr = fmt.Errorf("error when finding Order: %w\n", orderErr)
+	}
+
+	return &Data{
+		Req:         req,
+		ReqEvents:   reqEvents,
+		Certificate: crt,
+		CrtError:    crtErr,
+		Order:       order,
+		OrderError:  orderErr,
+	}, nil
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequest
+
+import (
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1beta1"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func generateCSR(t *testing.T) []byte {
+	template, err := pki.GenerateCSR(gen.Certificate("test-crt",
+		gen.SetCertificateCommonName("example.com"),
+		gen.SetCertificateDNSNames("example.com", "www.example.com"),
+		gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sk, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrDER, err := pki.EncodeCSR(template, sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+}
+
+func TestStatusString(t *testing.T) {
+	csr := generateCSR(t)
+	now := time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		data      *Data
+		expOutput string
+	}{
+		// Newlines are part of the expected output
+		"CR not owned by a Certificate with invalid CSR": {
+			data: &Data{
+				Req: gen.CertificateRequest("test-req",
+					gen.SetCertificateRequestNamespace("ns1"),
+					gen.SetCertificateRequestCSR([]byte("not a CSR")),
+					gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca-issuer"})),
+			},
+			expOutput: `CertificateRequest:
+  Name: test-req
+  Namespace: ns1
+  Conditions:
+    No Conditions set
+  error when decoding the certificate signing request: error decoding certificate request PEM block
+  Events:  <none>
+Certificate: <none>, the CertificateRequest is not owned by a Certificate
+Issuer:
+  Name: ca-issuer
+  Kind: Issuer
+  Group: cert-manager.io
+Issued Certificate: <none>
+`,
+		},
+		"CR with events aged relative to the clock": {
+			data: &Data{
+				Req: gen.CertificateRequest("test-req",
+					gen.SetCertificateRequestNamespace("ns1"),
+					gen.SetCertificateRequestCSR([]byte("not a CSR")),
+					gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca-issuer"})),
+				ReqEvents: &corev1.EventList{Items: []corev1.Event{{
+					Type: corev1.EventTypeNormal, Reason: "IssuerNotFound", Message: "Referenced issuer does not exist",
+					FirstTimestamp: metav1.NewTime(now.Add(-2 * time.Hour)), Source: corev1.EventSource{Component: "cert-manager"},
+				}}},
+			},
+			expOutput: `CertificateRequest:
+  Name: test-req
+  Namespace: ns1
+  Conditions:
+    No Conditions set
+  error when decoding the certificate signing request: error decoding certificate request PEM block
+  Events:
+    Type    Reason          Age   From          Message
+    ----    ------          ----  ----          -------
+    Normal  IssuerNotFound  120m  cert-manager  Referenced issuer does not exist
+Certificate: <none>, the CertificateRequest is not owned by a Certificate
+Issuer:
+  Name: ca-issuer
+  Kind: Issuer
+  Group: cert-manager.io
+Issued Certificate: <none>
+`,
+		},
+		"CR owned by a Certificate with decoded CSR": {
+			data: &Data{
+				Req: gen.CertificateRequest("test-req",
+					gen.SetCertificateRequestNamespace("ns1"),
+					gen.SetCertificateRequestCSR(csr),
+					gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "ClusterIssuer"}),
+					gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionFalse, Reason: "Pending", Message: "Waiting on issuance"})),
+				Certificate: gen.Certificate("test-crt"),
+			},
+			expOutput: `CertificateRequest:
+  Name: test-req
+  Namespace: ns1
+  Conditions:
+    Ready: False, Reason: Pending, Message: Waiting on issuance
+  Requested:
+    Common Name: example.com
+    DNS Names: example.com, www.example.com
+    IP Addresses: 
+    URIs: 
+    Email Addresses: 
+    Usages: digital signature, key encipherment (default)
+    Is CA: false
+  Events:  <none>
+Certificate: test-crt
+Issuer:
+  Name: ca-issuer
+  Kind: ClusterIssuer
+  Group: cert-manager.io
+Issued Certificate: <none>
+`,
+		},
+		"CR owned by a Certificate that cannot be found": {
+			data: &Data{
+				Req: gen.CertificateRequest("test-req",
+					gen.SetCertificateRequestNamespace("ns1"),
+					gen.SetCertificateRequestCSR(csr),
+					gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca-issuer"}),
+					gen.SetCertificateRequestCertificate([]byte("not a certificate"))),
+				CrtError: errors.New("error when getting Certificate \"test-crt\" owning the CertificateRequest: not found\n"),
+			},
+			expOutput: `CertificateRequest:
+  Name: test-req
+  Namespace: ns1
+  Conditions:
+    No Conditions set
+  Requested:
+    Common Name: example.com
+    DNS Names: example.com, www.example.com
+    IP Addresses: 
+    URIs: 
+    Email Addresses: 
+    Usages: digital signature, key encipherment (default)
+    Is CA: false
+  Events:  <none>
+error when getting Certificate "test-crt" owning the CertificateRequest: not found
+Issuer:
+  Name: ca-issuer
+  Kind: Issuer
+  Group: cert-manager.io
+error when parsing the issued certificate: error decoding certificate PEM block
+`,
+		},
+		"CR of an ACME Issuer followed to its Order": {
+			data: &Data{
+				Req: gen.CertificateRequest("test-req",
+					gen.SetCertificateRequestNamespace("ns1"),
+					gen.SetCertificateRequestCSR([]byte("not a CSR")),
+					gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "letsencrypt-prod"})),
+				Order: &cmacme.Order{ObjectMeta: metav1.ObjectMeta{Name: "test-order", Namespace: "ns1"},
+					Status: cmacme.OrderStatus{State: cmacme.Pending}},
+			},
+			expOutput: `CertificateRequest:
+  Name: test-req
+  Namespace: ns1
+  Conditions:
+    No Conditions set
+  error when decoding the certificate signing request: error decoding certificate request PEM block
+  Events:  <none>
+Certificate: <none>, the CertificateRequest is not owned by a Certificate
+Issuer:
+  Name: letsencrypt-prod
+  Kind: Issuer
+  Group: cert-manager.io
+Issued Certificate: <none>
+Order:
+  Name: test-order
+  State: pending, Reason: 
+  No Authorizations for this Order
+`,
+		},
+		"CR whose Orders could not be listed": {
+			data: &Data{
+				Req: gen.CertificateRequest("test-req",
+					gen.SetCertificateRequestNamespace("ns1"),
+					gen.SetCertificateRequestCSR([]byte("not a CSR")),
+					gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "letsencrypt-prod"})),
+				OrderError: errors.New("error when finding Order: forbidden\n"),
+			},
+			expOutput: `CertificateRequest:
+  Name: test-req
+  Namespace: ns1
+  Conditions:
+    No Conditions set
+  error when decoding the certificate signing request: error decoding certificate request PEM block
+  Events:  <none>
+Certificate: <none>, the CertificateRequest is not owned by a Certificate
+Issuer:
+  Name: letsencrypt-prod
+  Kind: Issuer
+  Group: cert-manager.io
+Issued Certificate: <none>
+error when finding Order: forbidden
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actualOutput := StatusFromResources(test.data, fakeclock.NewFakeClock(now)).String()
+			if strings.TrimSpace(actualOutput) != strings.TrimSpace(test.expOutput) {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestIssuedStatusString(t *testing.T) {
+	issuedStatus := &IssuedStatus{
+		SubjectCommonName: "example.com",
+		IssuerCommonName:  "ca",
+		SerialNumber:      big.NewInt(0x1a2b3c),
+		NotBefore:         time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:          time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC),
+	}
+	// The serial number is printed as by status certificate
+	expOutput := `Issued Certificate:
+  Subject Common Name: example.com
+  Issuer Common Name: ca
+  Serial Number: 1A:2B:3C
+  Not Before: 2020-06-01T00:00:00Z
+  Not After: 2020-09-01T00:00:00Z
+`
+	if actualOutput := issuedStatus.String(); actualOutput != expOutput {
+		t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", expOutput, actualOutput)
+	}
+}
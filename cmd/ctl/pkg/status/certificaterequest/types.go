@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequest
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/status/certificate"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+type CertificateRequestStatus struct {
+	// Status of the CertificateRequest resource itself
+	CRStatus *certificate.CRStatus
+	// Name of the Certificate owning the CertificateRequest, empty if not owned by a Certificate
+	CertificateName string
+	// If CertificateError is not nil, the CertificateRequest is owned by a Certificate
+	// that could not be found
+	CertificateError error
+	// Reference to the Issuer/ClusterIssuer of the CertificateRequest
+	IssuerRef cmmeta.ObjectReference
+	// Certificate issued for the CertificateRequest, nil if not issued yet
+	IssuedStatus *IssuedStatus
+	// Status of the ACME Order owned by the CertificateRequest, nil if there is none
+	OrderStatus *certificate.OrderStatus
+}
+
+type IssuedStatus struct {
+	// If Error is not nil, there was a problem decoding the issued certificate,
+	// so the rest of the fields is unusable
+	Error error
+	// Subject Common Name of the issued x509 certificate
+	SubjectCommonName string
+	// Issuer Common Name of the issued x509 certificate
+	IssuerCommonName string
+	// Serial Number of the issued x509 certificate
+	SerialNumber *big.Int
+	// Not Before of the issued x509 certificate
+	NotBefore time.Time
+	// Not After of the issued x509 certificate
+	NotAfter time.Time
+}
+
+// StatusFromResources takes in a Data struct and returns a CertificateRequestStatus built using
+// the information in data. Durations relative to the current time are computed using clock.
+func StatusFromResources(data *Data, clock clock.Clock) *CertificateRequestStatus {
+	req := data.Req
+	status := &CertificateRequestStatus{
+		CRStatus:         certificate.NewCRStatus(req, data.ReqEvents, nil, clock),
+		CertificateError: data.CrtError,
+		IssuerRef:        req.Spec.IssuerRef,
+		OrderStatus:      certificate.NewOrderStatus(data.Order, data.OrderError),
+	}
+	if data.Certificate != nil {
+		status.CertificateName = data.Certificate.Name
+	}
+	if len(req.Status.Certificate) > 0 {
+		status.IssuedStatus = issuedStatusFromCert(req.Status.Certificate)
+	}
+	return status
+}
+
+func issuedStatusFromCert(certPEM []byte) *IssuedStatus {
+	x509Cert, err := pki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		return &IssuedStatus{Error: fmt.Errorf("error when parsing the issued certificate: %s\n", err)}
+	}
+	return &IssuedStatus{
+		SubjectCommonName: x509Cert.Subject.CommonName,
+		IssuerCommonName:  x509Cert.Issuer.CommonName,
+		SerialNumber:      x509Cert.SerialNumber,
+		NotBefore:         x509Cert.NotBefore,
+		NotAfter:          x509Cert.NotAfter,
+	}
+}
+
+func (status *CertificateRequestStatus) String() string {
+	output := status.CRStatus.String()
+
+	switch {
+	case status.CertificateError != nil:
+		output += status.CertificateError.Error()
+	case status.CertificateName != "":
+		output += fmt.Sprintf("Certificate: %s\n", status.CertificateName)
+	default:
+		output += "Certificate: <none>, the CertificateRequest is not owned by a Certificate\n"
+	}
+
+	issuerKind := status.IssuerRef.Kind
+	if issuerKind == "" {
+		issuerKind = "Issuer"
+	}
+	issuerGroup := status.IssuerRef.Group
+	if issuerGroup == "" {
+		issuerGroup = cmapi.SchemeGroupVersion.Group
+	}
+	output += fmt.Sprintf("Issuer:\n  Name: %s\n  Kind: %s\n  Group: %s\n", status.IssuerRef.Name, issuerKind, issuerGroup)
+
+	if status.IssuedStatus == nil {
+		output += "Issued Certificate: <none>\n"
+	} else {
+		output += status.IssuedStatus.String()
+	}
+
+	// OrderStatus is nil if the Issuer of the CertificateRequest is not an ACME Issuer
+	if status.OrderStatus != nil {
+		output += status.OrderStatus.String()
+	}
+
+	return output
+}
+
+// String returns the information about the issued certificate as a string to be printed as output
+func (issuedStatus *IssuedStatus) String() string {
+	if issuedStatus.Error != nil {
+		return issuedStatus.Error.Error()
+	}
+
+	output := "Issued Certificate:\n"
+	output += fmt.Sprintf("  Subject Common Name: %s\n", issuedStatus.SubjectCommonName)
+	output += fmt.Sprintf("  Issuer Common Name: %s\n", issuedStatus.IssuerCommonName)
+	output += fmt.Sprintf("  Serial Number: %s\n", certificate.SerialNumberToString(issuedStatus.SerialNumber))
+	output += fmt.Sprintf("  Not Before: %s\n", issuedStatus.NotBefore.Format(time.RFC3339))
+	output += fmt.Sprintf("  Not After: %s\n", issuedStatus.NotAfter.Format(time.RFC3339))
+	return output
+}
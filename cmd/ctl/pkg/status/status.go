@@ -22,16 +22,18 @@ import (
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 
 	"github.com/jetstack/cert-manager/cmd/ctl/pkg/status/certificate"
+	"github.com/jetstack/cert-manager/cmd/ctl/pkg/status/certificaterequest"
 )
 
 func NewCmdStatus(ioStreams genericclioptions.IOStreams, factory cmdutil.Factory) *cobra.Command {
 	cmds := &cobra.Command{
 		Use:   "status",
 		Short: "Get details on current status of cert-manager resources",
-		Long:  `Get details on current status of cert-manager resources, e.g. Certificate`,
+		Long:  `Get details on current status of cert-manager resources, e.g. Certificate or CertificateRequest`,
 	}
 
 	cmds.AddCommand(certificate.NewCmdStatusCert(ioStreams, factory))
+	cmds.AddCommand(certificaterequest.NewCmdStatusCR(ioStreams, factory))
 
 	return cmds
 }
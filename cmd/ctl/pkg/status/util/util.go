@@ -39,23 +39,30 @@ import (
 // DescribeEvents writes a formatted string of the Events in el with PrefixWriter.
 // The intended use is for w to be created with a *tabWriter.Writer underneath, and the caller
 // of DescribeEvents would need to call Flush() on that *tabWriter.Writer to actually print the output.
-func DescribeEvents(el *corev1.EventList, w describe.PrefixWriter, baseLevel int) {
+// The age of the events is computed relative to now.
+func DescribeEvents(el *corev1.EventList, w describe.PrefixWriter, baseLevel int, now time.Time) {
+	DescribeEventsWithHeader(el, w, baseLevel, "Events", now)
+}
+
+// DescribeEventsWithHeader is DescribeEvents with header written in place of "Events",
+// e.g. to add how many events were left out of el.
+func DescribeEventsWithHeader(el *corev1.EventList, w describe.PrefixWriter, baseLevel int, header string, now time.Time) {
 	if el == nil || len(el.Items) == 0 {
-		w.Write(baseLevel, "Events:\t<none>\n")
+		w.Write(baseLevel, "%s:\t<none>\n", header)
 		w.Flush()
 		return
 	}
 	w.Flush()
 	sort.Sort(event.SortableEvents(el.Items))
-	w.Write(baseLevel, "Events:\n")
+	w.Write(baseLevel, "%s:\n", header)
 	w.Write(baseLevel+1, "Type\tReason\tAge\tFrom\tMessage\n")
 	w.Write(baseLevel+1, "----\t------\t----\t----\t-------\n")
 	for _, e := range el.Items {
 		var interval string
 		if e.Count > 1 {
-			interval = fmt.Sprintf("%s (x%d over %s)", translateTimestampSince(e.LastTimestamp), e.Count, translateTimestampSince(e.FirstTimestamp))
+			interval = fmt.Sprintf("%s (x%d over %s)", translateTimestampSince(e.LastTimestamp, now), e.Count, translateTimestampSince(e.FirstTimestamp, now))
 		} else {
-			interval = translateTimestampSince(e.FirstTimestamp)
+			interval = translateTimestampSince(e.FirstTimestamp, now)
 		}
 		w.Write(baseLevel+1, "%v\t%v\t%s\t%v\t%v\n",
 			e.Type,
@@ -82,12 +89,12 @@ func formatEventSource(es corev1.EventSource) string {
 	return strings.Join(EventSourceString, ", ")
 }
 
-// translateTimestampSince returns the elapsed time since timestamp in
+// translateTimestampSince returns the elapsed time from timestamp to now in
 // human-readable approximation.
-func translateTimestampSince(timestamp metav1.Time) string {
+func translateTimestampSince(timestamp metav1.Time, now time.Time) string {
 	if timestamp.IsZero() {
 		return "<unknown>"
 	}
 
-	return duration.HumanDuration(time.Since(timestamp.Time))
+	return duration.HumanDuration(now.Sub(timestamp.Time))
 }
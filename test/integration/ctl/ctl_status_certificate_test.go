@@ -174,12 +174,15 @@ MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 			inputNamespace: ns1,
 			clusterIssuer:  gen.ClusterIssuer("letsencrypt-prod", gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{})),
 			expErr:         false,
-			expOutput: `^Name: testcrt-1
+			expOutput: `^testcrt-1 \(testns-1\): Ready, expiry unknown via letsencrypt-prod
+Name: testcrt-1
 Namespace: testns-1
-Created at: ([0-9]+)-(0[1-9]|1[012])-(0[1-9]|[12][0-9]|3[01])[Tt]([01][0-9]|2[0-3]):([0-5][0-9]):([0-5][0-9]|60)(\.[0-9]+)?(([Zz])|([\+|\-]([01][0-9]|2[0-3]):[0-5][0-9]))
+Created at: ([0-9]+)-(0[1-9]|1[012])-(0[1-9]|[12][0-9]|3[01])[Tt]([01][0-9]|2[0-3]):([0-5][0-9]):([0-5][0-9]|60)(\.[0-9]+)?(([Zz])|([\+|\-]([01][0-9]|2[0-3]):[0-5][0-9])) \(.+ ago\)
+Revision: 1
+Last Issued: unknown, revision 1 was issued but the certificate in the Secret could not be read
 Conditions:
   Ready: True, Reason: , Message: Certificate is up to date and has not expired
-DNS Names:
+DNS Names \(spec\):
 - www.example.com
 Events:
   Type  Reason  Age        From  Message
@@ -188,13 +191,16 @@ Events:
 Issuer:
   Name: letsencrypt-prod
   Kind: ClusterIssuer
+  Group: cert-manager.io
+  Type: SelfSigned
   Conditions:
     No Conditions set
   Events:  <none>
-error when finding Secret "example-tls": secrets "example-tls" not found
-Not Before: <none>
-Not After: 2020-09-16T09:26:18Z
-Renewal Time: <none>
+Secret "example-tls" not created yet
+Not Before: not set
+Not After: 2020-09-16T09:26:18Z \(.+ ago\)
+Renewal Time: not set
+Requested Duration: 90d
 No CertificateRequest found for this Certificate$`,
 		},
 		"certificate issued and renewal in progress with Issuer": {
@@ -270,18 +276,28 @@ No CertificateRequest found for this Certificate$`,
 				),
 			},
 			expErr: false,
-			expOutput: `^Name: testcrt-2
+			expOutput: `^testcrt-2 \(testns-1\): Ready, (expires in|expired) .+ via letsencrypt-prod
+Name: testcrt-2
 Namespace: testns-1
-Created at: ([0-9]+)-(0[1-9]|1[012])-(0[1-9]|[12][0-9]|3[01])[Tt]([01][0-9]|2[0-3]):([0-5][0-9]):([0-5][0-9]|60)(\.[0-9]+)?(([Zz])|([\+|\-]([01][0-9]|2[0-3]):[0-5][0-9]))
+Created at: ([0-9]+)-(0[1-9]|1[012])-(0[1-9]|[12][0-9]|3[01])[Tt]([01][0-9]|2[0-3]):([0-5][0-9]):([0-5][0-9]|60)(\.[0-9]+)?(([Zz])|([\+|\-]([01][0-9]|2[0-3]):[0-5][0-9])) \(.+ ago\)
+Revision: 1 \(reissuance in progress\)
+Last Issued: .+ ago \(revision 1, 2020-07-30T16:11:43Z\)
+Renewal State: Overdue, the Certificate should have been renewed but is not being reissued or its certificate has expired
 Conditions:
   Ready: True, Reason: , Message: Certificate is up to date and has not expired
   Issuing: True, Reason: , Message: Issuance of a new Certificate is in Progress
-DNS Names:
+DNS Names \(spec\):
 - www.example.com
+DNS Names \(requested\): <none>
+DNS Names \(issued\): <none>
+  Missing from the certificate: www.example.com
 Events:  <none>
 Issuer:
   Name: letsencrypt-prod
   Kind: Issuer
+  Group: cert-manager.io
+  Type: ACME
+  Endpoint: https://dummy.acme.local/
   Conditions:
     No Conditions set
   Events:
@@ -290,36 +306,69 @@ Issuer:
     type  reason  <unknown>        message
 Secret:
   Name: existing-tls-secret
+  Type: Opaque
+  Owned by Certificate: false
   Issuer Country: 
   Issuer Organisation: 
   Issuer Common Name: test
+  Subject Country: 
+  Subject Organisation: 
+  Subject Organisational Unit: 
+  Subject Common Name: test
+  Subject Alternative Names:
+    DNS Names: 
+    IP Addresses: 
+    URIs: 
+    Email Addresses: 
   Key Usage: Digital Signature, Key Encipherment
   Extended Key Usages: 
-  Public Key Algorithm: RSA
+  Basic Constraints: CA: false
+  Public Key Algorithm: RSA \(2048 bit\)
   Signature Algorithm: SHA256-RSA
   Subject Key ID: 
   Authority Key ID: 
-  Serial Number: e2f88edc942c148463219da909fd633a
+  Version: 3
+  Serial Number: E2:F8:8E:DC:94:2C:14:84:63:21:9D:A9:09:FD:63:3A
+  Serial Number \(Decimal\): 301696114246524167282555582613204853562
+  Fingerprint \(SHA-256\): 1C:25:ED:E3:19:6A:B2:DB:E4:39:C8:A1:D0:02:86:AC:89:68:65:C3:65:59:4D:12:52:FE:FB:04:D8:01:14:CD
+  Validity: EXPIRED .* ago \(2020-10-28T16:11:43Z\)
   Events:
     Type  Reason  Age        From  Message
     ----  ------  ----       ----  -------
     type  reason  <unknown>        message
-Not Before: <none>
-Not After: 2020-09-16T09:26:18Z
-Renewal Time: <none>
+Not Before: not set
+Not After: 2020-09-16T09:26:18Z \(.+ ago\)
+Renewal Time: not set
+Requested Duration: 90d
+Actual Duration: 90d
 CertificateRequest:
   Name: testreq-1
   Namespace: testns-1
   Conditions:
     Ready: False, Reason: Pending, Message: Waiting on certificate issuance from order default/example-order: "pending"
+  Requested:
+    Common Name: test
+    DNS Names: 
+    IP Addresses: 
+    URIs: 
+    Email Addresses: 
+    Usages: digital signature, key encipherment \(default\)
+    Is CA: false
   Events:  <none>
 Order:
   Name: example-order
   State: , Reason: 
   No Authorizations for this Order
 Challenges:
-- Name: test-challenge1, Type: HTTP-01, Token: dummy-token1, Key: , State: , Reason: , Processing: false, Presented: false
-- Name: test-challenge2, Type: DNS-01, Token: dummy-token2, Key: , State: , Reason: , Processing: false, Presented: false$`,
+- Name: test-challenge1, Type: HTTP-01, DNS Name: , Token: dummy-token1, Key: , State: , Reason: , Processing: false, Presented: false
+- Name: test-challenge2, Type: DNS-01, DNS Name: , Token: dummy-token2, Key: , State: , Reason: , Processing: false, Presented: false
+CertificateRequest History:
+  Revision  Name       State    Age
+  2         testreq-1  Pending  .+
+Warnings:
+  warning: the DNS Names differ from those of the certificate in the Secret \(DNSNamesMismatch\)
+  error: the certificate in the Secret has expired \(CertificateExpired\)
+  warning: the Secret is not of type kubernetes.io/tls \(SecretWrongType\)$`,
 		},
 		"certificate issued and renewal in progress without Issuer": {
 			certificate: gen.Certificate(crt3Name,
@@ -350,29 +399,45 @@ Challenges:
 			},
 			issuer: nil,
 			expErr: false,
-			expOutput: `^Name: testcrt-3
+			expOutput: `^testcrt-3 \(testns-1\): Ready, expiry unknown
+Name: testcrt-3
 Namespace: testns-1
-Created at: ([0-9]+)-(0[1-9]|1[012])-(0[1-9]|[12][0-9]|3[01])[Tt]([01][0-9]|2[0-3]):([0-5][0-9]):([0-5][0-9]|60)(\.[0-9]+)?(([Zz])|([\+|\-]([01][0-9]|2[0-3]):[0-5][0-9]))
+Created at: ([0-9]+)-(0[1-9]|1[012])-(0[1-9]|[12][0-9]|3[01])[Tt]([01][0-9]|2[0-3]):([0-5][0-9]):([0-5][0-9]|60)(\.[0-9]+)?(([Zz])|([\+|\-]([01][0-9]|2[0-3]):[0-5][0-9])) \(.+ ago\)
+Revision: 1 \(reissuance in progress\)
+Last Issued: unknown, revision 1 was issued but the certificate in the Secret could not be read
 Conditions:
   Ready: True, Reason: , Message: Certificate is up to date and has not expired
   Issuing: True, Reason: , Message: Issuance of a new Certificate is in Progress
-DNS Names:
+DNS Names \(spec\):
 - www.example.com
+DNS Names \(requested\): <none>
 Events:  <none>
 error when getting Issuer: issuers.cert-manager.io "non-existing-issuer" not found
-error when finding Secret "example-tls": secrets "example-tls" not found
-Not Before: <none>
-Not After: 2020-09-16T09:26:18Z
-Renewal Time: <none>
+Secret "example-tls" not created yet \(issuance in progress\)
+Not Before: not set
+Not After: 2020-09-16T09:26:18Z \(.+ ago\)
+Renewal Time: not set
+Requested Duration: 90d
 CertificateRequest:
   Name: testreq-2
   Namespace: testns-1
   Conditions:
     Ready: False, Reason: Pending, Message: Waiting on certificate issuance from order default/example-order: "pending"
+  Requested:
+    Common Name: test
+    DNS Names: 
+    IP Addresses: 
+    URIs: 
+    Email Addresses: 
+    Usages: digital signature, key encipherment \(default\)
+    Is CA: false
   Events:
     Type  Reason  Age        From  Message
     ----  ------  ----       ----  -------
-    type  reason  <unknown>        message$`,
+    type  reason  <unknown>        message
+CertificateRequest History:
+  Revision  Name       State    Age
+  2         testreq-2  Pending  .+$`,
 		},
 		"certificate issued and renewal in progress without ClusterIssuer": {
 			certificate: gen.Certificate(crt4Name,
@@ -392,26 +457,42 @@ CertificateRequest:
 			reqStatus: &cmapi.CertificateRequestStatus{Conditions: []cmapi.CertificateRequestCondition{reqNotReadyCond}},
 			issuer:    nil,
 			expErr:    false,
-			expOutput: `^Name: testcrt-4
+			expOutput: `^testcrt-4 \(testns-1\): Ready, expiry unknown
+Name: testcrt-4
 Namespace: testns-1
-Created at: ([0-9]+)-(0[1-9]|1[012])-(0[1-9]|[12][0-9]|3[01])[Tt]([01][0-9]|2[0-3]):([0-5][0-9]):([0-5][0-9]|60)(\.[0-9]+)?(([Zz])|([\+|\-]([01][0-9]|2[0-3]):[0-5][0-9]))
+Created at: ([0-9]+)-(0[1-9]|1[012])-(0[1-9]|[12][0-9]|3[01])[Tt]([01][0-9]|2[0-3]):([0-5][0-9]):([0-5][0-9]|60)(\.[0-9]+)?(([Zz])|([\+|\-]([01][0-9]|2[0-3]):[0-5][0-9])) \(.+ ago\)
+Revision: 1 \(reissuance in progress\)
+Last Issued: unknown, revision 1 was issued but the certificate in the Secret could not be read
 Conditions:
   Ready: True, Reason: , Message: Certificate is up to date and has not expired
   Issuing: True, Reason: , Message: Issuance of a new Certificate is in Progress
-DNS Names:
+DNS Names \(spec\):
 - www.example.com
+DNS Names \(requested\): <none>
 Events:  <none>
 error when getting ClusterIssuer: clusterissuers.cert-manager.io "non-existing-clusterissuer" not found
-error when finding Secret "example-tls": secrets "example-tls" not found
-Not Before: <none>
-Not After: 2020-09-16T09:26:18Z
-Renewal Time: <none>
+Secret "example-tls" not created yet \(issuance in progress\)
+Not Before: not set
+Not After: 2020-09-16T09:26:18Z \(.+ ago\)
+Renewal Time: not set
+Requested Duration: 90d
 CertificateRequest:
   Name: testreq-3
   Namespace: testns-1
   Conditions:
     Ready: False, Reason: Pending, Message: Waiting on certificate issuance from order default/example-order: "pending"
-  Events:  <none>$`,
+  Requested:
+    Common Name: test
+    DNS Names: 
+    IP Addresses: 
+    URIs: 
+    Email Addresses: 
+    Usages: digital signature, key encipherment \(default\)
+    Is CA: false
+  Events:  <none>
+CertificateRequest History:
+  Revision  Name       State    Age
+  2         testreq-3  Pending  .+$`,
 		},
 	}
 
@@ -534,6 +615,7 @@ CertificateRequest:
 				RESTConfig: config,
 				IOStreams:  streams,
 				Namespace:  test.inputNamespace,
+				ShowEvents: true,
 			}
 
 			err = opts.Run(test.inputArgs)